@@ -0,0 +1,121 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FlyteProjectSpec defines the desired state of FlyteProject. The name of the FlyteProject is the id of the project in
+// flyte, FlyteProjects are cluster scoped because projects are global in flyte
+type FlyteProjectSpec struct {
+	// DisplayName is the name of the project shown by flyte, it defaults to the name of the FlyteProject
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Description describes the project
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Labels are set on the project in flyte
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Flyte configures how the project is managed with flyte admin
+	// +optional
+	Flyte *ClusterFlyteSpec `json:"flyte,omitempty"`
+}
+
+// ClusterFlyteSpec configures how a cluster scoped resource is managed with flyte admin
+type ClusterFlyteSpec struct {
+	// CredentialsSecretRef references a Secret with the `clientId` and `clientSecret` used to authenticate with flyte
+	// admin, instead of the credentials the operator is configured with
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="has(self.__namespace__) && self.__namespace__ != ''",message="the namespace of the Secret must be set"
+	CredentialsSecretRef *corev1.SecretReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// Condition reasons reported on the FlyteProject status
+const (
+	// ReasonProjectSynced is used when the project in flyte matches the spec
+	ReasonProjectSynced = "ProjectSynced"
+	// ReasonProjectSyncFailed is used when the project could not be created or updated in flyte
+	ReasonProjectSyncFailed = "ProjectSyncFailed"
+)
+
+// FlyteProjectStatus defines the observed state of FlyteProject
+type FlyteProjectStatus struct {
+	// Conditions holds the Ready condition of the latest reconciliation
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the metadata.generation of the spec that was last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is when the project was last created or updated in flyte to match the spec
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Adopted is true when the project already existed in flyte before the FlyteProject, it is left active in flyte
+	// when the FlyteProject is deleted
+	// +optional
+	Adopted bool `json:"adopted,omitempty"`
+
+	// Created is true when the operator created the project in flyte, or reactivated it from archived. It is recorded
+	// before the project is changed in flyte, so that the project is never adopted afterwards.
+	// +optional
+	Created bool `json:"created,omitempty"`
+
+	// LastError is the error message of the last failed attempt, it is cleared on success
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:validation:XValidation:rule="self.metadata.name.size() <= 63 && self.metadata.name.matches('^[a-z0-9]([-a-z0-9]*[a-z0-9])?$')",message="the name of a FlyteProject is the id of the flyte project and must be a DNS-1123 label"
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Adopted",type=boolean,JSONPath=`.status.adopted`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FlyteProject is the Schema for the flyteprojects API
+type FlyteProject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FlyteProjectSpec   `json:"spec,omitempty"`
+	Status FlyteProjectStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FlyteProjectList contains a list of FlyteProject
+type FlyteProjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FlyteProject `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FlyteProject{}, &FlyteProjectList{})
+}
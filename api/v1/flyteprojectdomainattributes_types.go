@@ -0,0 +1,198 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FlyteProjectDomainAttributesSpec defines the desired state of FlyteProjectDomainAttributes, the matchable attributes
+// of a flyte project and domain
+// +kubebuilder:validation:XValidation:rule="has(self.taskResources) || has(self.executionQueue) || has(self.executionClusterLabel) || has(self.workflowExecutionConfig)",message="at least one attribute must be set"
+type FlyteProjectDomainAttributesSpec struct {
+	// Project is the flyte project the attributes are set on
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="project is immutable"
+	Project string `json:"project"`
+
+	// Domain is the flyte domain the attributes are set on
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="domain is immutable"
+	Domain string `json:"domain"`
+
+	MatchableAttributes `json:",inline"`
+
+	// Flyte configures how the attributes are managed with flyte admin
+	// +optional
+	Flyte *ClusterFlyteSpec `json:"flyte,omitempty"`
+}
+
+// MatchableAttributes are the matchable attributes of a flyte project and domain. The attributes that are not set are
+// left to the defaults of flyte admin.
+type MatchableAttributes struct {
+	// TaskResources are the resources requested by the tasks that do not request their own, and the most they may
+	// request
+	// +optional
+	TaskResources *TaskResourceAttributes `json:"taskResources,omitempty"`
+
+	// ExecutionQueue selects the queue the executions are scheduled on
+	// +optional
+	ExecutionQueue *ExecutionQueueAttributes `json:"executionQueue,omitempty"`
+
+	// ExecutionClusterLabel is the label of the cluster the executions are scheduled on
+	// +optional
+	ExecutionClusterLabel string `json:"executionClusterLabel,omitempty"`
+
+	// WorkflowExecutionConfig is the default configuration of the workflow executions
+	// +optional
+	WorkflowExecutionConfig *WorkflowExecutionConfig `json:"workflowExecutionConfig,omitempty"`
+}
+
+// TaskResourceAttributes are the default resources of the tasks and the most they may request
+type TaskResourceAttributes struct {
+	// Defaults are the resources requested by the tasks that do not request their own
+	// +optional
+	Defaults TaskResourceSpec `json:"defaults,omitempty"`
+
+	// Limits are the most resources a task may request
+	// +optional
+	Limits TaskResourceSpec `json:"limits,omitempty"`
+}
+
+// TaskResourceSpec are the quantities of each resource of a task
+type TaskResourceSpec struct {
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+	// +optional
+	GPU *resource.Quantity `json:"gpu,omitempty"`
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// +optional
+	EphemeralStorage *resource.Quantity `json:"ephemeralStorage,omitempty"`
+}
+
+// ExecutionQueueAttributes are the tags matched against the execution queues configured in flyte propeller
+type ExecutionQueueAttributes struct {
+	// Tags select the execution queue
+	// +kubebuilder:validation:MinItems=1
+	Tags []string `json:"tags"`
+}
+
+// WorkflowExecutionConfig is the default configuration of the workflow executions
+type WorkflowExecutionConfig struct {
+	// MaxParallelism is the most nodes of an execution that run at once
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxParallelism int32 `json:"maxParallelism,omitempty"`
+
+	// ServiceAccountName is the kubernetes service account the executions run as
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// RawOutputDataPrefix is where the executions write their raw output data, such as s3://bucket/prefix
+	// +optional
+	RawOutputDataPrefix string `json:"rawOutputDataPrefix,omitempty"`
+
+	// Labels are set on the executions
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are set on the executions
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Interruptible runs the executions on interruptible nodes, such as spot instances
+	// +optional
+	Interruptible *bool `json:"interruptible,omitempty"`
+
+	// OverwriteCache runs the cached tasks of the executions again and overwrites the cached outputs
+	// +optional
+	OverwriteCache bool `json:"overwriteCache,omitempty"`
+}
+
+// Condition reasons reported on the FlyteProjectDomainAttributes status
+const (
+	// ReasonAttributesApplied is used when the attributes in flyte match the spec
+	ReasonAttributesApplied = "AttributesApplied"
+	// ReasonAttributesSyncFailed is used when the attributes could not be read, updated or deleted in flyte
+	ReasonAttributesSyncFailed = "AttributesSyncFailed"
+)
+
+// FlyteProjectDomainAttributesStatus defines the observed state of FlyteProjectDomainAttributes
+type FlyteProjectDomainAttributesStatus struct {
+	// Conditions holds the Ready condition of the latest reconciliation
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the metadata.generation of the spec that was last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Applied are the attributes last applied to the project and domain in flyte, the attributes removed from the
+	// spec are deleted from flyte
+	// +optional
+	Applied *MatchableAttributes `json:"applied,omitempty"`
+
+	// LastSyncTime is when the attributes were last updated in flyte to match the spec
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastDriftTime is when the attributes in flyte were last found to have been changed outside of the operator
+	// +optional
+	LastDriftTime *metav1.Time `json:"lastDriftTime,omitempty"`
+
+	// LastError is the error message of the last failed attempt, it is cleared on success
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+//+kubebuilder:validation:XValidation:rule="self.metadata.name == self.spec.project + '.' + self.spec.domain",message="the name of a FlyteProjectDomainAttributes must be <project>.<domain>"
+//+kubebuilder:printcolumn:name="Project",type=string,JSONPath=`.spec.project`
+//+kubebuilder:printcolumn:name="Domain",type=string,JSONPath=`.spec.domain`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FlyteProjectDomainAttributes is the Schema for the flyteprojectdomainattributes API. It is cluster scoped and named
+// after its project and domain, so that there is a single FlyteProjectDomainAttributes for each project and domain.
+type FlyteProjectDomainAttributes struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FlyteProjectDomainAttributesSpec   `json:"spec,omitempty"`
+	Status FlyteProjectDomainAttributesStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FlyteProjectDomainAttributesList contains a list of FlyteProjectDomainAttributes
+type FlyteProjectDomainAttributesList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FlyteProjectDomainAttributes `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FlyteProjectDomainAttributes{}, &FlyteProjectDomainAttributesList{})
+}
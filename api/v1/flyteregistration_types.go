@@ -17,7 +17,35 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ForceRegistrationAnnotation can be set to "true" on a FlyteRegistration to register the workflow package again, even
+// when the spec has already been registered successfully. The operator removes the annotation once the registration
+// it forced has succeeded.
+const ForceRegistrationAnnotation = "flyte.backend/force-registration"
+
+// ReconcileRequestedAtAnnotation can be set on a FlyteRegistration, to a new value such as the current time, to download
+// and register the workflow package again once even when the spec has already been registered successfully
+const ReconcileRequestedAtAnnotation = "flyte.backend/reconcile-requested-at"
+
+// Finalizer is added to a FlyteRegistration with the Archive deletion policy, so that the registered entities can be
+// archived in flyte before the FlyteRegistration is removed
+const Finalizer = "flyte.backend/finalizer"
+
+// DeletionPolicy decides what happens to the entities registered in flyte when a FlyteRegistration is deleted
+// +kubebuilder:validation:Enum=Retain;Archive
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyRetain leaves the registered entities live in flyte
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyArchive deactivates the launch plans and archives the workflows listed in the registered entities
+	// of the status. Flyte archives a workflow with all of its versions, the workflows other FlyteRegistrations use are
+	// left active.
+	DeletionPolicyArchive DeletionPolicy = "Archive"
 )
 
 // FlyteRegistrationSpec defines the desired state of FlyteRegistration
@@ -25,17 +53,392 @@ type FlyteRegistrationSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	// WorkflowDomain is the domain of the workflow - we can have multiple domains on one flyte.backend cluster
+	// WorkflowDomain is the domain of the workflow - we can have multiple domains on one flyte.backend cluster.
+	// It is defaulted to the domain the operator is configured with by the defaulting webhook, and cannot be changed
 	WorkflowDomain string `json:"workflowDomain"`
 
-	// WorkflowProject is the project of the workflow - we can have multiple projects on one flyte.backend cluster
+	// WorkflowProject is the project of the workflow - we can have multiple projects on one flyte.backend cluster.
+	// It cannot be changed
 	WorkflowProject string `json:"workflowProject"`
 
-	// WorkflowPackageURI is the URI of the workflow artifact packaged by pyflyte in CI and stored in s3 storage
+	// WorkflowPackageURI is the URI of the workflow artifact packaged by pyflyte in CI.
+	// The scheme of the URI, e.g. `oci://`, `jfrog://`, `s3://` or `https://`, selects how the artifact is downloaded,
+	// URIs without a scheme are downloaded with the download strategy the operator is configured with. The key of an
+	// `s3://bucket/key` URI may hold a `{version}` placeholder, and a `?versionId=` query parameter pins a version of the
+	// object. The URL of an `https://` URI may hold a `{version}` placeholder too
 	WorkflowPackageURI string `json:"workflowPackageUri"`
 
-	// WorkflowVersion is the version of the workflow
+	// WorkflowVersion is the version of the workflow. It must be set unless a VersionPolicy is set
+	// +optional
+	WorkflowVersion string `json:"workflowVersion,omitempty"`
+
+	// VersionPolicy is a semver range, e.g. `~1.4` or `>=2.0.0 <3`, used instead of the WorkflowVersion. The operator
+	// lists the versions of the workflow package at its source, OCI tags or JFrog paths, and registers the highest
+	// version that matches the range. The versions are listed again every VersionPollInterval
+	// +optional
+	VersionPolicy string `json:"versionPolicy,omitempty"`
+
+	// VersionPollInterval is how often the versions are listed for the VersionPolicy, it defaults to the interval the
+	// operator is configured with
+	// +optional
+	VersionPollInterval *metav1.Duration `json:"versionPollInterval,omitempty"`
+
+	// PackageDigest pins the sha256 digest of the workflow package, in the form `sha256:<hex>`. The package is only
+	// registered when it resolves to this digest, for OCI packages it may be the digest of the manifest or of the layer
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	// +optional
+	PackageDigest string `json:"packageDigest,omitempty"`
+
+	// Targets are the flyte projects and domains the workflow package is registered into, e.g. to promote the same
+	// package to several domains. The package is downloaded once for each version and registered into every target,
+	// without targets it is registered into the WorkflowProject and WorkflowDomain
+	// +listType=atomic
+	// +optional
+	Targets []RegistrationTarget `json:"targets,omitempty"`
+
+	// LaunchPlans are the launch plans of the workflow package that are activated or deactivated once it has been
+	// registered, flytectl does not activate the launch plans it registers. Activating the registered version of a
+	// launch plan deactivates the version that was active before
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	LaunchPlans []LaunchPlanSpec `json:"launchPlans,omitempty"`
+
+	// SmokeTest launches an execution of a launch plan of the workflow package once it has been registered, the
+	// FlyteRegistration is only Ready once the execution has succeeded
+	// +optional
+	SmokeTest *SmokeTestSpec `json:"smokeTest,omitempty"`
+
+	// RollbackOnFailure restores the launch plans of the spec to the last version that was registered successfully when
+	// a new version fails to register or fails its smoke test. When the version is rejected by flyte admin, does not
+	// match its digest or signature, or fails its smoke test, the FlyteRegistration reports the Degraded condition and
+	// the failed version is not registered again until the spec changes or a reconciliation is requested. Other
+	// failures are retried with backoff
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+
+	// Source configures how the workflow package is downloaded
+	// +optional
+	Source *SourceSpec `json:"source,omitempty"`
+
+	// Flyte configures how the workflow package is registered with flyte admin
+	// +optional
+	Flyte *FlyteSpec `json:"flyte,omitempty"`
+
+	// WaitForProject makes the operator wait for the FlyteProject of each project the workflow package is registered
+	// into to be Ready before registering it
+	// +optional
+	WaitForProject bool `json:"waitForProject,omitempty"`
+
+	// Suspend stops the operator from downloading and registering the workflow package, e.g. during a flyte
+	// maintenance window, until it is set back to false. A suspended FlyteRegistration reports the Suspended condition
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// DeletionPolicy decides whether the launch plans and workflows registered from the workflow package are
+	// deactivated and archived in flyte when the FlyteRegistration is deleted
+	// +kubebuilder:default=Retain
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// RegistrationTarget is a flyte project and domain the workflow package is registered into
+type RegistrationTarget struct {
+	// Domain is the flyte domain the workflow package is registered in
+	Domain string `json:"domain"`
+
+	// Project is the flyte project the workflow package is registered in, it defaults to the WorkflowProject
+	// +optional
+	Project string `json:"project,omitempty"`
+
+	// WorkflowVersion is the version registered into this target, it defaults to the WorkflowVersion or the version
+	// the VersionPolicy resolved to
+	// +optional
+	WorkflowVersion string `json:"workflowVersion,omitempty"`
+}
+
+// LaunchPlanState is the state a launch plan is put in once the workflow package has been registered
+// +kubebuilder:validation:Enum=Active;Inactive
+type LaunchPlanState string
+
+const (
+	// LaunchPlanStateActive activates the registered version of the launch plan, so that its schedules launch executions
+	LaunchPlanStateActive LaunchPlanState = "Active"
+	// LaunchPlanStateInactive deactivates the registered version of the launch plan and the version that was active
+	LaunchPlanStateInactive LaunchPlanState = "Inactive"
+)
+
+// LaunchPlanSpec is a launch plan of the workflow package that is activated or deactivated
+type LaunchPlanSpec struct {
+	// Name is the name of the launch plan
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// State is whether the launch plan is activated or deactivated
+	// +kubebuilder:default=Active
+	// +optional
+	State LaunchPlanState `json:"state,omitempty"`
+}
+
+// SmokeTestSpec is an execution launched to check that a freshly registered version of the workflow package runs
+type SmokeTestSpec struct {
+	// LaunchPlan is the name of the launch plan executed, with the registered version, in the first target
+	// +kubebuilder:validation:MinLength=1
+	LaunchPlan string `json:"launchPlan"`
+
+	// Inputs are the inputs of the execution by name
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Inputs *runtime.RawExtension `json:"inputs,omitempty"`
+
+	// Timeout is how long the execution may run before it is terminated and the smoke test fails
+	// +kubebuilder:default="30m"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// RollbackLaunchPlans restores the launch plans of the spec to the versions that were active before the
+	// registration when the smoke test fails, in every target
+	// +optional
+	RollbackLaunchPlans bool `json:"rollbackLaunchPlans,omitempty"`
+}
+
+// SourceSpec configures how the workflow package is downloaded
+type SourceSpec struct {
+	// Type is the download strategy used for a workflow package URI without a scheme. It is defaulted from the
+	// scheme of the URI, or the download strategy the operator is configured with, by the defaulting webhook
+	// +kubebuilder:validation:Enum=oci;jfrog;s3;https
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// CredentialsSecretRef references a Secret in the namespace of the FlyteRegistration with the `username` and
+	// `password`, or the bearer `token`, used to download the workflow package, instead of the credentials the operator
+	// is configured with
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// FlyteSpec configures how the workflow package is registered with flyte admin
+type FlyteSpec struct {
+	// CredentialsSecretRef references a Secret in the namespace of the FlyteRegistration with the `clientId` and
+	// `clientSecret` used to authenticate with flyte admin, instead of the credentials the operator is configured with
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// Condition types reported on the FlyteRegistration status
+const (
+	// ConditionTypeDownloaded is true when the workflow package has been downloaded from its source
+	ConditionTypeDownloaded = "Downloaded"
+	// ConditionTypeVerified is true when the signature of the workflow package has been verified, it is only reported
+	// in the domains that require signed workflow packages
+	ConditionTypeVerified = "Verified"
+	// ConditionTypeRegistered is true when the workflow package has been registered with flyte admin
+	ConditionTypeRegistered = "Registered"
+	// ConditionTypeSmokeTested is true when the smoke test of the registered version has succeeded, it is only
+	// reported when the spec has a smoke test
+	ConditionTypeSmokeTested = "SmokeTested"
+	// ConditionTypeReady is true when the latest spec has been fully reconciled
+	ConditionTypeReady = "Ready"
+	// ConditionTypeDegraded is true when a version failed and the launch plans were rolled back to the last version
+	// registered successfully, it is removed once a version is Ready again
+	ConditionTypeDegraded = "Degraded"
+	// ConditionTypeSuspended is true while the FlyteRegistration is suspended, it is removed when it is resumed
+	ConditionTypeSuspended = "Suspended"
+)
+
+// Condition reasons reported on the FlyteRegistration status
+const (
+	// ReasonDownloadSucceeded is used when the workflow package was downloaded
+	ReasonDownloadSucceeded = "DownloadSucceeded"
+	// ReasonDownloadFailed is used when the workflow package could not be downloaded
+	ReasonDownloadFailed = "DownloadFailed"
+	// ReasonRegistrationSucceeded is used when the workflow package was registered
+	ReasonRegistrationSucceeded = "RegistrationSucceeded"
+	// ReasonRegistrationFailed is used when the workflow package could not be registered
+	ReasonRegistrationFailed = "RegistrationFailed"
+	// ReasonCredentialsUnavailable is used when the credentials referenced by the spec could not be read
+	ReasonCredentialsUnavailable = "CredentialsUnavailable"
+	// ReasonDigestMismatch is used when the downloaded workflow package does not have the pinned digest
+	ReasonDigestMismatch = "DigestMismatch"
+	// ReasonSignatureVerified is used when the workflow package is signed by a trusted key
+	ReasonSignatureVerified = "SignatureVerified"
+	// ReasonSignatureVerificationFailed is used when the workflow package is not signed by a trusted key
+	ReasonSignatureVerificationFailed = "SignatureVerificationFailed"
+	// ReasonProjectNotReady is used while the FlyteRegistration waits for the FlyteProject of a project to be Ready
+	ReasonProjectNotReady = "ProjectNotReady"
+	// ReasonLaunchPlanUpdateFailed is used when a launch plan could not be activated or deactivated
+	ReasonLaunchPlanUpdateFailed = "LaunchPlanUpdateFailed"
+	// ReasonSmokeTestRunning is used while the execution of the smoke test runs
+	ReasonSmokeTestRunning = "SmokeTestRunning"
+	// ReasonSmokeTestSucceeded is used when the execution of the smoke test succeeded
+	ReasonSmokeTestSucceeded = "SmokeTestSucceeded"
+	// ReasonSmokeTestFailed is used when the execution of the smoke test failed or did not complete in time
+	ReasonSmokeTestFailed = "SmokeTestFailed"
+	// ReasonSmokeTestLaunchFailed is used when the execution of the smoke test could not be launched or followed
+	ReasonSmokeTestLaunchFailed = "SmokeTestLaunchFailed"
+	// ReasonRolledBack is used when the launch plans were rolled back to the last version registered successfully
+	ReasonRolledBack = "RolledBack"
+	// ReasonSuspended is used when the FlyteRegistration is suspended
+	ReasonSuspended = "Suspended"
+	// ReasonVersionResolutionFailed is used when the version policy could not be resolved to a published version
+	ReasonVersionResolutionFailed = "VersionResolutionFailed"
+)
+
+// RegisteredEntities lists the names of the entities registered from a workflow package by resource type
+type RegisteredEntities struct {
+	// Count is the number of entities registered, including any left out of the lists
+	Count int `json:"count"`
+
+	// Truncated is true when the lists were capped and do not hold every registered entity
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Tasks are the names of the registered tasks
+	// +optional
+	Tasks []string `json:"tasks,omitempty"`
+
+	// Workflows are the names of the registered workflows
+	// +optional
+	Workflows []string `json:"workflows,omitempty"`
+
+	// LaunchPlans are the names of the registered launch plans
+	// +optional
+	LaunchPlans []string `json:"launchPlans,omitempty"`
+}
+
+// TargetStatus is the outcome of the latest registration of the workflow package into a target
+type TargetStatus struct {
+	// Project is the flyte project of the target
+	Project string `json:"project"`
+
+	// Domain is the flyte domain of the target
+	Domain string `json:"domain"`
+
+	// Ready is true when the latest registration into the target succeeded
+	Ready bool `json:"ready"`
+
+	// WorkflowVersion is the version last registered successfully into the target
+	// +optional
+	WorkflowVersion string `json:"workflowVersion,omitempty"`
+
+	// ResolvedDigest is the digest of the workflow package last registered successfully into the target
+	// +optional
+	ResolvedDigest string `json:"resolvedDigest,omitempty"`
+
+	// LastSuccessTime is when the workflow package was last registered successfully into the target
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+
+	// LastError is the error message of the latest registration into the target, it is cleared on success
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// LaunchPlanStatus is the state of a launch plan of the spec in a target
+type LaunchPlanStatus struct {
+	// Project is the flyte project of the target
+	Project string `json:"project"`
+
+	// Domain is the flyte domain of the target
+	Domain string `json:"domain"`
+
+	// Name is the name of the launch plan
+	Name string `json:"name"`
+
+	// ActiveVersion is the version of the launch plan that was activated, it is empty when the launch plan was
+	// deactivated
+	// +optional
+	ActiveVersion string `json:"activeVersion,omitempty"`
+}
+
+// SmokeTestOutcome is the outcome of the smoke test of a registered version
+type SmokeTestOutcome string
+
+const (
+	// SmokeTestOutcomeRunning is the outcome while the execution runs
+	SmokeTestOutcomeRunning SmokeTestOutcome = "Running"
+	// SmokeTestOutcomeSucceeded is the outcome of an execution that succeeded
+	SmokeTestOutcomeSucceeded SmokeTestOutcome = "Succeeded"
+	// SmokeTestOutcomeFailed is the outcome of an execution that failed or was aborted
+	SmokeTestOutcomeFailed SmokeTestOutcome = "Failed"
+	// SmokeTestOutcomeTimedOut is the outcome of an execution that was terminated after the timeout
+	SmokeTestOutcomeTimedOut SmokeTestOutcome = "TimedOut"
+)
+
+// SmokeTestStatus is the state of the smoke test of the registered version
+type SmokeTestStatus struct {
+	// Project is the flyte project the execution was launched in
+	Project string `json:"project"`
+
+	// Domain is the flyte domain the execution was launched in
+	Domain string `json:"domain"`
+
+	// ExecutionID is the name of the execution
+	ExecutionID string `json:"executionId"`
+
+	// WorkflowVersion is the version of the launch plan executed
+	WorkflowVersion string `json:"workflowVersion"`
+
+	// Phase is the phase of the execution in flyte when it was last polled
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Outcome is the outcome of the smoke test
+	Outcome SmokeTestOutcome `json:"outcome"`
+
+	// Message is the error the execution failed with
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is when the execution was launched
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is when the execution was seen completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// PreviousLaunchPlans holds the active version of each launch plan of the spec in each target before the
+	// registration, the launch plans are restored to them when the smoke test fails
+	// +optional
+	PreviousLaunchPlans []LaunchPlanStatus `json:"previousLaunchPlans,omitempty"`
+
+	// RolledBack is true once the launch plans have been restored to the versions that were active before
+	// +optional
+	RolledBack bool `json:"rolledBack,omitempty"`
+}
+
+// RegisteredVersion is a version of the workflow package that was registered successfully
+type RegisteredVersion struct {
+	// WorkflowVersion is the version registered
 	WorkflowVersion string `json:"workflowVersion"`
+
+	// Digest is the digest the workflow package resolved to
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// RegisteredTime is when the version became Ready
+	RegisteredTime metav1.Time `json:"registeredTime"`
+
+	// LaunchPlans holds the active version of each launch plan of the spec in each target once the version was
+	// registered, the launch plans are restored to them on rollback
+	// +optional
+	LaunchPlans []LaunchPlanStatus `json:"launchPlans,omitempty"`
+}
+
+// RollbackStatus records the rollback of a version that failed to the last version registered successfully
+type RollbackStatus struct {
+	// FailedVersion is the version that failed
+	FailedVersion string `json:"failedVersion"`
+
+	// FailedSpecHash is the hash of the spec that failed, it is not registered again until the spec changes or a
+	// reconciliation is requested
+	FailedSpecHash string `json:"failedSpecHash"`
+
+	// RestoredVersion is the version the launch plans were restored to
+	RestoredVersion string `json:"restoredVersion"`
+
+	// Time is when the launch plans were restored
+	Time metav1.Time `json:"time"`
 }
 
 // FlyteRegistrationStatus defines the observed state of FlyteRegistration
@@ -43,6 +446,94 @@ type FlyteRegistrationStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
+	// Conditions holds the Downloaded, Verified, Registered, SmokeTested and Ready conditions of the latest
+	// reconciliation, the Degraded condition after a rollback and the Suspended condition while the FlyteRegistration is
+	// suspended
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the metadata.generation of the spec that was last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAttemptTime is when the operator last attempted to register the workflow package
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// LastSuccessTime is when the workflow package was last registered successfully
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+
+	// LastError is the error message of the last failed attempt, it is cleared on success
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// Targets holds the outcome of the latest registration into each target, failed targets do not stop the workflow
+	// package from being registered into the others
+	// +optional
+	// +listType=map
+	// +listMapKey=project
+	// +listMapKey=domain
+	Targets []TargetStatus `json:"targets,omitempty"`
+
+	// LaunchPlans holds the active version of each launch plan of the spec in each target
+	// +optional
+	// +listType=map
+	// +listMapKey=project
+	// +listMapKey=domain
+	// +listMapKey=name
+	LaunchPlans []LaunchPlanStatus `json:"launchPlans,omitempty"`
+
+	// SmokeTest holds the state of the smoke test of the registered version
+	// +optional
+	SmokeTest *SmokeTestStatus `json:"smokeTest,omitempty"`
+
+	// History lists the last versions that were registered successfully, the most recent first, so that the live version
+	// is known while a new version fails
+	// +optional
+	// +listType=atomic
+	History []RegisteredVersion `json:"history,omitempty"`
+
+	// Rollback records the last rollback to a version registered successfully, it is cleared once a version is Ready
+	// again
+	// +optional
+	Rollback *RollbackStatus `json:"rollback,omitempty"`
+
+	// RegisteredEntities lists the entities registered from the workflow package with the last successful registration,
+	// into the first target when there are several
+	// +optional
+	RegisteredEntities *RegisteredEntities `json:"registeredEntities,omitempty"`
+
+	// ResolvedDigest is the digest the workflow package resolved to with the last successful registration, for OCI
+	// packages it is the digest of the manifest
+	// +optional
+	ResolvedDigest string `json:"resolvedDigest,omitempty"`
+
+	// ResolvedETag is the ETag of the object the workflow package was downloaded from with the last successful
+	// registration, for packages in object storage
+	// +optional
+	ResolvedETag string `json:"resolvedETag,omitempty"`
+
+	// ResolvedVersionID is the version ID of the object the workflow package was downloaded from with the last
+	// successful registration, for packages in versioned object storage
+	// +optional
+	ResolvedVersionID string `json:"resolvedVersionId,omitempty"`
+
+	// ResolvedVersion is the version the VersionPolicy resolved to when it was last polled
+	// +optional
+	ResolvedVersion string `json:"resolvedVersion,omitempty"`
+
+	// LastHandledReconcileAt is the value of the reconcile-requested-at annotation that was last handled
+	// +optional
+	LastHandledReconcileAt string `json:"lastHandledReconcileAt,omitempty"`
+
+	// LastRegisteredSpecHash is the hash of the spec that was last registered successfully, it is used to skip
+	// registering a spec that has not changed
+	// +optional
+	LastRegisteredSpecHash string `json:"lastRegisteredSpecHash,omitempty"`
+
 	// WorkflowDomain is the domain of the workflow - we can have multiple domains on one flyte.backend cluster
 	WorkflowDomain string `json:"workflowDomain"`
 
@@ -58,6 +549,15 @@ type FlyteRegistrationStatus struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Project",type=string,JSONPath=`.spec.workflowProject`
+//+kubebuilder:printcolumn:name="Domain",type=string,JSONPath=`.spec.workflowDomain`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.workflowVersion`
+//+kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.versionPolicy`,priority=1
+//+kubebuilder:printcolumn:name="Registered",type=string,JSONPath=`.status.workflowVersion`,priority=1
+//+kubebuilder:printcolumn:name="Live",type=string,JSONPath=`.status.history[0].workflowVersion`,priority=1
+//+kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspend`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // FlyteRegistration is the Schema for the flyteregistrations API
 type FlyteRegistration struct {
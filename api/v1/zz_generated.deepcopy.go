@@ -21,16 +21,285 @@ limitations under the License.
 package v1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFlyteSpec) DeepCopyInto(out *ClusterFlyteSpec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFlyteSpec.
+func (in *ClusterFlyteSpec) DeepCopy() *ClusterFlyteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFlyteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionQueueAttributes) DeepCopyInto(out *ExecutionQueueAttributes) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionQueueAttributes.
+func (in *ExecutionQueueAttributes) DeepCopy() *ExecutionQueueAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionQueueAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlyteProject) DeepCopyInto(out *FlyteProject) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteProject.
+func (in *FlyteProject) DeepCopy() *FlyteProject {
+	if in == nil {
+		return nil
+	}
+	out := new(FlyteProject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlyteProject) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlyteProjectDomainAttributes) DeepCopyInto(out *FlyteProjectDomainAttributes) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteProjectDomainAttributes.
+func (in *FlyteProjectDomainAttributes) DeepCopy() *FlyteProjectDomainAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(FlyteProjectDomainAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlyteProjectDomainAttributes) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlyteProjectDomainAttributesList) DeepCopyInto(out *FlyteProjectDomainAttributesList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FlyteProjectDomainAttributes, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteProjectDomainAttributesList.
+func (in *FlyteProjectDomainAttributesList) DeepCopy() *FlyteProjectDomainAttributesList {
+	if in == nil {
+		return nil
+	}
+	out := new(FlyteProjectDomainAttributesList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlyteProjectDomainAttributesList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlyteProjectDomainAttributesSpec) DeepCopyInto(out *FlyteProjectDomainAttributesSpec) {
+	*out = *in
+	in.MatchableAttributes.DeepCopyInto(&out.MatchableAttributes)
+	if in.Flyte != nil {
+		in, out := &in.Flyte, &out.Flyte
+		*out = new(ClusterFlyteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteProjectDomainAttributesSpec.
+func (in *FlyteProjectDomainAttributesSpec) DeepCopy() *FlyteProjectDomainAttributesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FlyteProjectDomainAttributesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlyteProjectDomainAttributesStatus) DeepCopyInto(out *FlyteProjectDomainAttributesStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = new(MatchableAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDriftTime != nil {
+		in, out := &in.LastDriftTime, &out.LastDriftTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteProjectDomainAttributesStatus.
+func (in *FlyteProjectDomainAttributesStatus) DeepCopy() *FlyteProjectDomainAttributesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlyteProjectDomainAttributesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlyteProjectList) DeepCopyInto(out *FlyteProjectList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FlyteProject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteProjectList.
+func (in *FlyteProjectList) DeepCopy() *FlyteProjectList {
+	if in == nil {
+		return nil
+	}
+	out := new(FlyteProjectList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FlyteProjectList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlyteProjectSpec) DeepCopyInto(out *FlyteProjectSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Flyte != nil {
+		in, out := &in.Flyte, &out.Flyte
+		*out = new(ClusterFlyteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteProjectSpec.
+func (in *FlyteProjectSpec) DeepCopy() *FlyteProjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FlyteProjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlyteProjectStatus) DeepCopyInto(out *FlyteProjectStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteProjectStatus.
+func (in *FlyteProjectStatus) DeepCopy() *FlyteProjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlyteProjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlyteRegistration) DeepCopyInto(out *FlyteRegistration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteRegistration.
@@ -86,6 +355,36 @@ func (in *FlyteRegistrationList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlyteRegistrationSpec) DeepCopyInto(out *FlyteRegistrationSpec) {
 	*out = *in
+	if in.VersionPollInterval != nil {
+		in, out := &in.VersionPollInterval, &out.VersionPollInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]RegistrationTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.LaunchPlans != nil {
+		in, out := &in.LaunchPlans, &out.LaunchPlans
+		*out = make([]LaunchPlanSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.SmokeTest != nil {
+		in, out := &in.SmokeTest, &out.SmokeTest
+		*out = new(SmokeTestSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(SourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Flyte != nil {
+		in, out := &in.Flyte, &out.Flyte
+		*out = new(FlyteSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteRegistrationSpec.
@@ -101,6 +400,55 @@ func (in *FlyteRegistrationSpec) DeepCopy() *FlyteRegistrationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlyteRegistrationStatus) DeepCopyInto(out *FlyteRegistrationStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LaunchPlans != nil {
+		in, out := &in.LaunchPlans, &out.LaunchPlans
+		*out = make([]LaunchPlanStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SmokeTest != nil {
+		in, out := &in.SmokeTest, &out.SmokeTest
+		*out = new(SmokeTestStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]RegisteredVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegisteredEntities != nil {
+		in, out := &in.RegisteredEntities, &out.RegisteredEntities
+		*out = new(RegisteredEntities)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteRegistrationStatus.
@@ -112,3 +460,340 @@ func (in *FlyteRegistrationStatus) DeepCopy() *FlyteRegistrationStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlyteSpec) DeepCopyInto(out *FlyteSpec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlyteSpec.
+func (in *FlyteSpec) DeepCopy() *FlyteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FlyteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaunchPlanSpec) DeepCopyInto(out *LaunchPlanSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaunchPlanSpec.
+func (in *LaunchPlanSpec) DeepCopy() *LaunchPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LaunchPlanStatus) DeepCopyInto(out *LaunchPlanStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LaunchPlanStatus.
+func (in *LaunchPlanStatus) DeepCopy() *LaunchPlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LaunchPlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchableAttributes) DeepCopyInto(out *MatchableAttributes) {
+	*out = *in
+	if in.TaskResources != nil {
+		in, out := &in.TaskResources, &out.TaskResources
+		*out = new(TaskResourceAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecutionQueue != nil {
+		in, out := &in.ExecutionQueue, &out.ExecutionQueue
+		*out = new(ExecutionQueueAttributes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkflowExecutionConfig != nil {
+		in, out := &in.WorkflowExecutionConfig, &out.WorkflowExecutionConfig
+		*out = new(WorkflowExecutionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchableAttributes.
+func (in *MatchableAttributes) DeepCopy() *MatchableAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchableAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegisteredEntities) DeepCopyInto(out *RegisteredEntities) {
+	*out = *in
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workflows != nil {
+		in, out := &in.Workflows, &out.Workflows
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LaunchPlans != nil {
+		in, out := &in.LaunchPlans, &out.LaunchPlans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegisteredEntities.
+func (in *RegisteredEntities) DeepCopy() *RegisteredEntities {
+	if in == nil {
+		return nil
+	}
+	out := new(RegisteredEntities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegisteredVersion) DeepCopyInto(out *RegisteredVersion) {
+	*out = *in
+	in.RegisteredTime.DeepCopyInto(&out.RegisteredTime)
+	if in.LaunchPlans != nil {
+		in, out := &in.LaunchPlans, &out.LaunchPlans
+		*out = make([]LaunchPlanStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegisteredVersion.
+func (in *RegisteredVersion) DeepCopy() *RegisteredVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(RegisteredVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistrationTarget) DeepCopyInto(out *RegistrationTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationTarget.
+func (in *RegistrationTarget) DeepCopy() *RegistrationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistrationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackStatus) DeepCopyInto(out *RollbackStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackStatus.
+func (in *RollbackStatus) DeepCopy() *RollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestSpec) DeepCopyInto(out *SmokeTestSpec) {
+	*out = *in
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestSpec.
+func (in *SmokeTestSpec) DeepCopy() *SmokeTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SmokeTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmokeTestStatus) DeepCopyInto(out *SmokeTestStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousLaunchPlans != nil {
+		in, out := &in.PreviousLaunchPlans, &out.PreviousLaunchPlans
+		*out = make([]LaunchPlanStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmokeTestStatus.
+func (in *SmokeTestStatus) DeepCopy() *SmokeTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SmokeTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceSpec) DeepCopyInto(out *SourceSpec) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceSpec.
+func (in *SourceSpec) DeepCopy() *SourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetStatus) DeepCopyInto(out *TargetStatus) {
+	*out = *in
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetStatus.
+func (in *TargetStatus) DeepCopy() *TargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskResourceAttributes) DeepCopyInto(out *TaskResourceAttributes) {
+	*out = *in
+	in.Defaults.DeepCopyInto(&out.Defaults)
+	in.Limits.DeepCopyInto(&out.Limits)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskResourceAttributes.
+func (in *TaskResourceAttributes) DeepCopy() *TaskResourceAttributes {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskResourceAttributes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskResourceSpec) DeepCopyInto(out *TaskResourceSpec) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EphemeralStorage != nil {
+		in, out := &in.EphemeralStorage, &out.EphemeralStorage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskResourceSpec.
+func (in *TaskResourceSpec) DeepCopy() *TaskResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowExecutionConfig) DeepCopyInto(out *WorkflowExecutionConfig) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Interruptible != nil {
+		in, out := &in.Interruptible, &out.Interruptible
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowExecutionConfig.
+func (in *WorkflowExecutionConfig) DeepCopy() *WorkflowExecutionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowExecutionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
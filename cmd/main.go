@@ -36,6 +36,7 @@ import (
 	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/controller"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/webhook"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -98,7 +99,8 @@ func main() {
 	}
 
 	// The reconciler instantiation is modified here to inject the config
-	flyteController, err := controller.NewFlyteRegistrationReconciler(config, mgr.GetClient(), mgr.GetScheme())
+	flyteController, err := controller.NewFlyteRegistrationReconciler(config, mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme(),
+		mgr.GetEventRecorderFor("flyteregistration-controller"))
 	if err != nil {
 		setupLog.Error(err, "unable to create flyte registration reconciler")
 		os.Exit(1)
@@ -110,6 +112,30 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "FlyteRegistration")
 		os.Exit(1)
 	}
+
+	// The projects and their attributes are managed with the flyte client of the registrations, so that they share its
+	// rate limit
+	projectController := controller.NewFlyteProjectReconciler(config, mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme(),
+		mgr.GetEventRecorderFor("flyteproject-controller"), flyteController.FlyteAdminClient)
+	if err := projectController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FlyteProject")
+		os.Exit(1)
+	}
+
+	attributesController := controller.NewFlyteProjectDomainAttributesReconciler(config, mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme(),
+		mgr.GetEventRecorderFor("flyteprojectdomainattributes-controller"), flyteController.FlyteAdminClient)
+	if err := attributesController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FlyteProjectDomainAttributes")
+		os.Exit(1)
+	}
+
+	// The webhooks need a serving certificate, so they are only served when they are enabled
+	if config.EnableWebhooks {
+		if err := webhook.SetupWithManager(mgr, config); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FlyteRegistration")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
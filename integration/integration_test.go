@@ -29,6 +29,7 @@ import (
 	dMocks "github.com/adarga-ai/flyte-workflow-registration-operator/internal/downloader/mocks"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
 	fMocks "github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte/mocks"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/workspace"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -56,6 +57,9 @@ func TestIntegration(t *testing.T) {
 
 	artifactPath := "test-artifact-path"
 
+	// Without a credentials Secret reference the configured credentials are used
+	var noCredentials *internal.Credentials
+
 	meta := flyte.WorkflowMetadata{
 		WorkflowVersion: workflowVersion,
 		Domain:          workflowDomain,
@@ -74,9 +78,11 @@ func TestIntegration(t *testing.T) {
 	mockDownloader := dMocks.Client{}
 	mockFlyteClient := fMocks.Client{}
 
-	mockDownloader.EXPECT().DownloadArtifact(mock.Anything, workflowPackageURI, workflowVersion).Return(artifactPath, nil).Once()
+	mockDownloader.EXPECT().DownloadArtifact(mock.Anything, mock.MatchedBy(func(req internal.DownloadRequest) bool {
+		return req.URI == workflowPackageURI && req.Version == workflowVersion && req.Credentials == noCredentials
+	})).Return(internal.Artifact{Path: artifactPath}, nil).Once()
 
-	mockFlyteClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil).Once()
+	mockFlyteClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
 
 	testEnv = &envtest.Environment{
 		CRDDirectoryPaths:     []string{filepath.Join("..", "config", "crd", "bases")},
@@ -118,6 +124,8 @@ func TestIntegration(t *testing.T) {
 		Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
 		Downloader:       &mockDownloader,
 		FlyteAdminClient: &mockFlyteClient,
+		Workspaces:       workspace.NewManager(t.TempDir(), 0),
+		Recorder:         k8sManager.GetEventRecorderFor("flyteregistration-controller"),
 	}
 
 	err = r.SetupWithManager(k8sManager)
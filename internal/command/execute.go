@@ -19,6 +19,7 @@ package command
 
 import (
 	"context"
+	"os"
 	"os/exec"
 )
 
@@ -27,6 +28,7 @@ import (
 //go:generate mockery --name=Executor
 type Executor interface {
 	ExecuteCommand(ctx context.Context, command string, args ...string) ([]byte, error)
+	ExecuteCommandWithEnv(ctx context.Context, env []string, command string, args ...string) ([]byte, error)
 }
 
 // OSCommandExecutor is an instance of Executor
@@ -42,3 +44,11 @@ func (e *OSCommandExecutor) ExecuteCommand(ctx context.Context, command string,
 	cmd := exec.CommandContext(ctx, command, args...)
 	return cmd.CombinedOutput()
 }
+
+// ExecuteCommandWithEnv executes a given os command with extra environment variables, in the form key=value, on top of
+// the environment of the current process
+func (e *OSCommandExecutor) ExecuteCommandWithEnv(ctx context.Context, env []string, command string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Env = append(os.Environ(), env...)
+	return cmd.CombinedOutput()
+}
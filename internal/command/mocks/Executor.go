@@ -95,6 +95,81 @@ func (_c *Executor_ExecuteCommand_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// ExecuteCommandWithEnv provides a mock function with given fields: ctx, env, _a2, args
+func (_m *Executor) ExecuteCommandWithEnv(ctx context.Context, env []string, _a2 string, args ...string) ([]byte, error) {
+	_va := make([]interface{}, len(args))
+	for _i := range args {
+		_va[_i] = args[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, env, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteCommandWithEnv")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, ...string) ([]byte, error)); ok {
+		return rf(ctx, env, _a2, args...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, ...string) []byte); ok {
+		r0 = rf(ctx, env, _a2, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string, ...string) error); ok {
+		r1 = rf(ctx, env, _a2, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Executor_ExecuteCommandWithEnv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteCommandWithEnv'
+type Executor_ExecuteCommandWithEnv_Call struct {
+	*mock.Call
+}
+
+// ExecuteCommandWithEnv is a helper method to define mock.On call
+//   - ctx context.Context
+//   - env []string
+//   - _a2 string
+//   - args ...string
+func (_e *Executor_Expecter) ExecuteCommandWithEnv(ctx interface{}, env interface{}, _a2 interface{}, args ...interface{}) *Executor_ExecuteCommandWithEnv_Call {
+	return &Executor_ExecuteCommandWithEnv_Call{Call: _e.mock.On("ExecuteCommandWithEnv",
+		append([]interface{}{ctx, env, _a2}, args...)...)}
+}
+
+func (_c *Executor_ExecuteCommandWithEnv_Call) Run(run func(ctx context.Context, env []string, _a2 string, args ...string)) *Executor_ExecuteCommandWithEnv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), args[1].([]string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *Executor_ExecuteCommandWithEnv_Call) Return(_a0 []byte, _a1 error) *Executor_ExecuteCommandWithEnv_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Executor_ExecuteCommandWithEnv_Call) RunAndReturn(run func(context.Context, []string, string, ...string) ([]byte, error)) *Executor_ExecuteCommandWithEnv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewExecutor creates a new instance of Executor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewExecutor(t interface {
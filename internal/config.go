@@ -18,9 +18,14 @@ limitations under the License.
 package internal
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/alexflint/go-arg"
+
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/workspace"
 )
 
 // DownloadStrategyOCI is a value for the Downloadstrategy env var that is set to ensure artifacts are downloaded from
@@ -31,6 +36,17 @@ const DownloadStrategyOCI = "oci"
 // JFrog
 const DownloadStrategyJFrog = "jfrog"
 
+// DownloadStrategyS3 is a value for the Downloadstrategy env var that is set to ensure artifacts are downloaded from
+// S3
+const DownloadStrategyS3 = "s3"
+
+// DownloadStrategyHTTPS is a value for the Downloadstrategy env var that is set to ensure artifacts are downloaded from
+// HTTPS servers
+const DownloadStrategyHTTPS = "https"
+
+// DownloadStrategies are the values allowed for the Downloadstrategy env var
+var DownloadStrategies = []string{DownloadStrategyOCI, DownloadStrategyJFrog, DownloadStrategyS3, DownloadStrategyHTTPS}
+
 // OCIAuthStrategyStatic is a value for the OCIAuthStrategy env var, when set to this OCI will be authenticated to
 // statically
 const OCIAuthStrategyStatic = "static"
@@ -39,6 +55,61 @@ const OCIAuthStrategyStatic = "static"
 // OCI
 const OCIAuthStrategyECR = "ecr"
 
+// VersionPlaceholder is replaced with the version of the workflow package in the package URIs of the S3 and HTTPS
+// download strategies
+const VersionPlaceholder = "{version}"
+
+// Credentials are a username and password, or a bearer token, that override the credentials in the Config for a
+// single request
+type Credentials struct {
+	Username string
+	Password string
+	// Token is a bearer token used instead of the username and password by the download strategies that support it
+	Token string
+}
+
+// DownloadRequest describes a workflow package to download
+type DownloadRequest struct {
+	// URI is the URI of the workflow package
+	URI string
+	// Version is the version of the workflow package
+	Version string
+	// Credentials, when set, override the credentials in the Config
+	Credentials *Credentials
+	// Workspace is where the workflow package is downloaded to, the space of the downloaded files is reserved in it
+	Workspace *workspace.Workspace
+	// Digest, when set, is the sha256 digest the downloaded workflow package must have
+	Digest string
+	// VerifySignature requires the workflow package to be signed by a trusted key
+	VerifySignature bool
+}
+
+// VersionsRequest describes a workflow package to list the published versions of
+type VersionsRequest struct {
+	// URI is the URI of the workflow package
+	URI string
+	// Credentials, when set, override the credentials in the Config
+	Credentials *Credentials
+}
+
+// ErrVersionsUnsupported is returned by the download strategies that cannot list the versions of a workflow package
+var ErrVersionsUnsupported = errors.New("listing versions is not supported")
+
+// ErrTokenUnsupported is returned for token credentials by the download strategies that cannot authenticate with a token
+var ErrTokenUnsupported = errors.New("token credentials are not supported")
+
+// Artifact is a downloaded workflow package
+type Artifact struct {
+	// Path is the path of the downloaded file
+	Path string
+	// Digest is the digest the workflow package resolved to
+	Digest string
+	// ETag is the ETag of the object the workflow package was downloaded from, for object storage
+	ETag string
+	// VersionID is the version ID of the object the workflow package was downloaded from, for versioned object storage
+	VersionID string
+}
+
 // Config is the configuration to run the service
 // args are parsed from go-arg, https://github.com/alexflint/go-arg
 // Add here service config arguments and add the specific arg tag
@@ -46,6 +117,42 @@ type Config struct {
 	DownloadStrategy string `arg:"env:DOWNLOADER_STRATEGY" default:"oci"`
 	LogLevel         string `arg:"env:LOG_LEVEL" default:"info"`
 
+	// Workspace config, the workflow packages are downloaded to a workspace under the root directory, the temp
+	// directory when it is empty. The downloads in all the workspaces are capped to the max bytes, 0 means no cap
+	WorkspaceRoot     string `arg:"env:WORKSPACE_ROOT"`
+	WorkspaceMaxBytes int64  `arg:"env:WORKSPACE_MAX_BYTES" default:"0"`
+
+	// Concurrency config, the failed reconciliations are retried with an exponential backoff from the base to the max
+	// backoff. The requests to each registry host and to flyte admin are rate limited with a token bucket, there is
+	// no limit when the requests per second are 0
+	MaxConcurrentReconciles int           `arg:"env:MAX_CONCURRENT_RECONCILES" default:"1"`
+	ReconcileBaseBackoff    time.Duration `arg:"env:RECONCILE_BASE_BACKOFF" default:"1s"`
+	ReconcileMaxBackoff     time.Duration `arg:"env:RECONCILE_MAX_BACKOFF" default:"5m"`
+	RegistryRateLimit       float64       `arg:"env:REGISTRY_RATE_LIMIT" default:"5"`
+	RegistryRateBurst       int           `arg:"env:REGISTRY_RATE_BURST" default:"10"`
+	FlyteAdminRateLimit     float64       `arg:"env:FLYTE_ADMIN_RATE_LIMIT" default:"5"`
+	FlyteAdminRateBurst     int           `arg:"env:FLYTE_ADMIN_RATE_BURST" default:"10"`
+
+	// Version policy config, the versions of the workflow packages with a version policy are listed again every poll
+	// interval unless the FlyteRegistration sets its own
+	VersionPollInterval time.Duration `arg:"env:VERSION_POLL_INTERVAL" default:"5m"`
+
+	// Project config, the FlyteProjects and FlyteProjectDomainAttributes are compared with flyte again every sync
+	// interval, so that changes made in flyte are reverted
+	ProjectSyncInterval time.Duration `arg:"env:PROJECT_SYNC_INTERVAL" default:"10m"`
+
+	// Smoke test config, the executions of the smoke tests are polled every poll interval until they complete
+	SmokeTestPollInterval time.Duration `arg:"env:SMOKE_TEST_POLL_INTERVAL" default:"30s"`
+
+	// Webhook config
+	EnableWebhooks        bool   `arg:"env:ENABLE_WEBHOOKS" default:"false"`
+	DefaultWorkflowDomain string `arg:"env:DEFAULT_WORKFLOW_DOMAIN" default:"development"`
+
+	// Signature verification config, the workflow packages registered in the required domains must be signed by one
+	// of the PEM public keys or certificates in the trusted keys file or directory, `*` requires it in every domain
+	SignatureRequiredDomains []string `arg:"env:SIGNATURE_REQUIRED_DOMAINS"`
+	SignatureTrustedKeys     string   `arg:"env:SIGNATURE_TRUSTED_KEYS"`
+
 	// JFrog config
 	JfrogURL      string `arg:"env:JFROG_ARTIFACTORY_URL"`
 	JfrogUser     string `arg:"env:JFROG_USER"`
@@ -57,10 +164,26 @@ type Config struct {
 	OCIUsername     string `arg:"env:OCI_USERNAME"`
 	OCIPassword     string `arg:"env:OCI_PASSWORD"`
 
+	// S3 config, the s3 strategy is available when it is the default strategy or a region is configured. The endpoint
+	// and path style addressing are only needed for S3 compatible object storage
+	S3Region       string `arg:"env:S3_REGION"`
+	S3Endpoint     string `arg:"env:S3_ENDPOINT"`
+	S3UsePathStyle bool   `arg:"env:S3_USE_PATH_STYLE" default:"false"`
+
+	// HTTPS config, the CA bundle is a PEM file of certificates trusted besides the system roots. When a checksum is
+	// required the packages without a `.sha256` sidecar file are refused
+	HTTPSCABundle        string `arg:"env:HTTPS_CA_BUNDLE"`
+	HTTPSRequireChecksum bool   `arg:"env:HTTPS_REQUIRE_CHECKSUM" default:"false"`
+
 	// Flyte config
 	FlyteAdminEndpoint string `arg:"env:FLYTE_ADMIN_ENDPOINT"`
 	FlyteClientID      string `arg:"env:FLYTE_CLIENT_ID"`
 	FlyteClientSecret  string `arg:"env:FLYTE_CLIENT_SECRET"`
+
+	// The Secret with the `clientId` and `clientSecret` of the flyte client. When a namespace is set the credentials
+	// are read from the Secret through the Kubernetes API instead of FLYTE_CLIENT_ID and FLYTE_CLIENT_SECRET
+	FlyteCredentialsSecretName      string `arg:"env:FLYTE_CREDENTIALS_SECRET" default:"flyte-credentials"`
+	FlyteCredentialsSecretNamespace string `arg:"env:FLYTE_CREDENTIALS_SECRET_NAMESPACE"`
 }
 
 // NewConfig return a new instance of Config
@@ -72,13 +195,49 @@ func NewConfig() (Config, error) {
 	}
 
 	// Validate the config
-	if config.DownloadStrategy != DownloadStrategyOCI && config.DownloadStrategy != DownloadStrategyJFrog {
-		return Config{}, fmt.Errorf("invalid download strategy: %s, only `oci` or `jfrog` allowed", config.DownloadStrategy)
+	if !slices.Contains(DownloadStrategies, config.DownloadStrategy) {
+		return Config{}, fmt.Errorf("invalid download strategy: %s, only `oci`, `jfrog`, `s3` or `https` allowed", config.DownloadStrategy)
 	}
 
 	if config.OCIAuthStrategy != OCIAuthStrategyStatic && config.OCIAuthStrategy != OCIAuthStrategyECR {
 		return Config{}, fmt.Errorf("invalid OCI auth strategy: %s, only `static` or `ecr` allowed", config.OCIAuthStrategy)
 	}
 
+	if config.MaxConcurrentReconciles < 1 {
+		return Config{}, fmt.Errorf("invalid max concurrent reconciles: %d, at least 1 required", config.MaxConcurrentReconciles)
+	}
+
+	if config.ReconcileBaseBackoff <= 0 || config.ReconcileMaxBackoff < config.ReconcileBaseBackoff {
+		return Config{}, fmt.Errorf("invalid reconcile backoff: base %s, max %s, the base must be positive and not above the max",
+			config.ReconcileBaseBackoff, config.ReconcileMaxBackoff)
+	}
+
+	if config.RegistryRateBurst < 1 || config.FlyteAdminRateBurst < 1 {
+		return Config{}, fmt.Errorf("invalid rate limit burst: registry %d, flyte admin %d, at least 1 required",
+			config.RegistryRateBurst, config.FlyteAdminRateBurst)
+	}
+
+	if config.VersionPollInterval <= 0 {
+		return Config{}, fmt.Errorf("invalid version poll interval: %s, must be positive", config.VersionPollInterval)
+	}
+
+	if config.ProjectSyncInterval <= 0 {
+		return Config{}, fmt.Errorf("invalid project sync interval: %s, must be positive", config.ProjectSyncInterval)
+	}
+
+	if config.SmokeTestPollInterval <= 0 {
+		return Config{}, fmt.Errorf("invalid smoke test poll interval: %s, must be positive", config.SmokeTestPollInterval)
+	}
+
+	if len(config.SignatureRequiredDomains) > 0 && config.SignatureTrustedKeys == "" {
+		return Config{}, fmt.Errorf("signatures are required in domains %v but no trusted keys are configured",
+			config.SignatureRequiredDomains)
+	}
+
 	return config, nil
 }
+
+// SignatureRequired returns true when the workflow packages registered in the domain must be signed
+func (c Config) SignatureRequired(domain string) bool {
+	return slices.Contains(c.SignatureRequiredDomains, domain) || slices.Contains(c.SignatureRequiredDomains, "*")
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/downloader"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
+)
+
+// Keys of the Secrets referenced by a FlyteRegistration
+const (
+	usernameKey     = "username"
+	passwordKey     = "password"
+	tokenKey        = "token"
+	clientIDKey     = "clientId"
+	clientSecretKey = "clientSecret"
+)
+
+// sourceCredentials returns the credentials to download the workflow package with, or nil when the FlyteRegistration
+// does not reference a Secret and the configured credentials should be used. A Secret with a token holds a bearer
+// token, otherwise it must hold a username and password. S3 cannot authenticate with a token, so a token for an S3
+// package is refused rather than downloading the package with the configured credentials.
+func (r *FlyteRegistrationReconciler) sourceCredentials(ctx context.Context, flyteWorkflow *v1.FlyteRegistration) (*internal.Credentials, error) {
+	if flyteWorkflow.Spec.Source == nil || flyteWorkflow.Spec.Source.CredentialsSecretRef == nil {
+		return nil, nil
+	}
+
+	secret, err := getSecret(ctx, r.APIReader, flyteWorkflow.Namespace, flyteWorkflow.Spec.Source.CredentialsSecretRef.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source credentials: %w", err)
+	}
+
+	if token, ok := secret.Data[tokenKey]; ok {
+		if strategy := downloader.Strategy(flyteWorkflow.Spec.WorkflowPackageURI, flyteWorkflow.Spec.Source.Type, r.Config.DownloadStrategy); strategy == internal.DownloadStrategyS3 {
+			return nil, fmt.Errorf("failed to read source credentials: %w for s3 packages", internal.ErrTokenUnsupported)
+		}
+		return &internal.Credentials{Token: string(token)}, nil
+	}
+
+	data, err := secretData(secret, usernameKey, passwordKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source credentials: %w", err)
+	}
+
+	return &internal.Credentials{
+		Username: data[usernameKey],
+		Password: data[passwordKey],
+	}, nil
+}
+
+// flyteAuth returns the auth to register the workflow package with
+func (r *FlyteRegistrationReconciler) flyteAuth(ctx context.Context, flyteWorkflow *v1.FlyteRegistration) (flyte.Auth, error) {
+	return resolveFlyteAuth(ctx, r.APIReader, r.Config, flyteSecretRef(flyteWorkflow.Namespace, flyteWorkflow.Spec.Flyte))
+}
+
+// flyteSecretRef returns the Secret referenced by the flyte spec of a resource in the given namespace, the Secret is in
+// the namespace of the resource
+func flyteSecretRef(namespace string, flyteSpec *v1.FlyteSpec) *corev1.SecretReference {
+	if flyteSpec == nil || flyteSpec.CredentialsSecretRef == nil {
+		return nil
+	}
+
+	return &corev1.SecretReference{Namespace: namespace, Name: flyteSpec.CredentialsSecretRef.Name}
+}
+
+// clusterFlyteSecretRef returns the Secret referenced by the flyte spec of a cluster scoped resource
+func clusterFlyteSecretRef(flyteSpec *v1.ClusterFlyteSpec) *corev1.SecretReference {
+	if flyteSpec == nil {
+		return nil
+	}
+
+	return flyteSpec.CredentialsSecretRef
+}
+
+// resolveFlyteAuth returns the auth to call flyte admin with for a resource. The client in the Secret referenced by the
+// resource is used when there is one, otherwise the configured client is used.
+func resolveFlyteAuth(ctx context.Context, reader client.Reader, config internal.Config, secretRef *corev1.SecretReference) (flyte.Auth, error) {
+	if secretRef != nil {
+		return flyteAuthFromSecret(ctx, reader, config, secretRef.Namespace, secretRef.Name)
+	}
+
+	if config.FlyteCredentialsSecretNamespace != "" {
+		return flyteAuthFromSecret(ctx, reader, config, config.FlyteCredentialsSecretNamespace, config.FlyteCredentialsSecretName)
+	}
+
+	// Without a Secret the client secret is read by flytectl from the environment of the operator
+	return flyte.Auth{
+		AdminEndpoint:      config.FlyteAdminEndpoint,
+		ClientID:           config.FlyteClientID,
+		ClientSecretEnvVar: flyte.ClientSecretEnvVar,
+	}, nil
+}
+
+// flyteAuthFromSecret returns the auth for the client in the given Secret. Secrets are read from the API server on each
+// reconciliation, so a rotated secret is used by the next registration without restarting the operator.
+func flyteAuthFromSecret(ctx context.Context, reader client.Reader, config internal.Config, namespace string, name string) (flyte.Auth, error) {
+	secret, err := getSecret(ctx, reader, namespace, name)
+	if err != nil {
+		return flyte.Auth{}, fmt.Errorf("failed to read flyte credentials: %w", err)
+	}
+
+	data, err := secretData(secret, clientIDKey, clientSecretKey)
+	if err != nil {
+		return flyte.Auth{}, fmt.Errorf("failed to read flyte credentials: %w", err)
+	}
+
+	return flyte.Auth{
+		AdminEndpoint:      config.FlyteAdminEndpoint,
+		ClientID:           data[clientIDKey],
+		ClientSecretEnvVar: flyte.ClientSecretEnvVar,
+		ClientSecret:       data[clientSecretKey],
+	}, nil
+}
+
+// getSecret reads a Secret with a reader that does not go through the cache of the manager, so that the operator does
+// not watch every Secret in the cluster
+func getSecret(ctx context.Context, reader client.Reader, namespace string, name string) (*corev1.Secret, error) {
+	var secret corev1.Secret
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+
+	return &secret, nil
+}
+
+// secretData reads the given keys of a Secret, all of the keys must be set
+func secretData(secret *corev1.Secret, keys ...string) (map[string]string, error) {
+	data := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("secret %s has no %s key", secret.Name, key)
+		}
+		data[key] = string(value)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+)
+
+// Reasons of the Events emitted on a FlyteRegistration, the outcomes share their reason with the matching condition
+const (
+	// EventReasonDownloadStarted is emitted when the workflow package starts downloading
+	EventReasonDownloadStarted = "DownloadStarted"
+	// EventReasonDownloadSucceeded is emitted when the workflow package was downloaded
+	EventReasonDownloadSucceeded = v1.ReasonDownloadSucceeded
+	// EventReasonDownloadFailed is emitted when the workflow package could not be downloaded
+	EventReasonDownloadFailed = v1.ReasonDownloadFailed
+	// EventReasonRegistrationSucceeded is emitted when the workflow package was registered
+	EventReasonRegistrationSucceeded = v1.ReasonRegistrationSucceeded
+	// EventReasonRegistrationFailed is emitted when the workflow package could not be registered
+	EventReasonRegistrationFailed = v1.ReasonRegistrationFailed
+	// EventReasonDigestMismatch is emitted when the workflow package does not have the pinned digest
+	EventReasonDigestMismatch = v1.ReasonDigestMismatch
+	// EventReasonDigestChanged is emitted when the same version of the workflow package resolves to another digest than
+	// it did with the last successful registration
+	EventReasonDigestChanged = "DigestChanged"
+	// EventReasonSignatureVerificationFailed is emitted when the workflow package is not signed by a trusted key
+	EventReasonSignatureVerificationFailed = v1.ReasonSignatureVerificationFailed
+	// EventReasonVersionResolved is emitted when the version policy resolves to another version than it did before
+	EventReasonVersionResolved = "VersionResolved"
+	// EventReasonVersionResolutionFailed is emitted when the version policy could not be resolved to a published version
+	EventReasonVersionResolutionFailed = v1.ReasonVersionResolutionFailed
+	// EventReasonSuspended is emitted when the FlyteRegistration is suspended
+	EventReasonSuspended = v1.ReasonSuspended
+	// EventReasonResumed is emitted when a suspended FlyteRegistration is resumed
+	EventReasonResumed = "Resumed"
+	// EventReasonSkippedUnchanged is emitted when the spec has already been registered and is not registered again
+	EventReasonSkippedUnchanged = "SkippedUnchanged"
+	// EventReasonLaunchPlanActivated is emitted when the registered version of a launch plan was activated
+	EventReasonLaunchPlanActivated = "LaunchPlanActivated"
+	// EventReasonLaunchPlanDeactivated is emitted when a version of a launch plan was deactivated
+	EventReasonLaunchPlanDeactivated = "LaunchPlanDeactivated"
+	// EventReasonLaunchPlanUpdateFailed is emitted when a launch plan could not be activated or deactivated
+	EventReasonLaunchPlanUpdateFailed = v1.ReasonLaunchPlanUpdateFailed
+	// EventReasonProjectNotReady is emitted when the FlyteRegistration starts waiting for the FlyteProject of a project
+	EventReasonProjectNotReady = v1.ReasonProjectNotReady
+	// EventReasonSmokeTestStarted is emitted when the execution of the smoke test was launched
+	EventReasonSmokeTestStarted = "SmokeTestStarted"
+	// EventReasonSmokeTestSucceeded is emitted when the execution of the smoke test succeeded
+	EventReasonSmokeTestSucceeded = v1.ReasonSmokeTestSucceeded
+	// EventReasonSmokeTestFailed is emitted when the execution of the smoke test failed or did not complete in time
+	EventReasonSmokeTestFailed = v1.ReasonSmokeTestFailed
+	// EventReasonSmokeTestLaunchFailed is emitted when the execution of the smoke test could not be launched or followed
+	EventReasonSmokeTestLaunchFailed = v1.ReasonSmokeTestLaunchFailed
+	// EventReasonRolledBack is emitted when a version failed and the launch plans were rolled back to the last version
+	// registered successfully
+	EventReasonRolledBack = v1.ReasonRolledBack
+	// EventReasonLaunchPlansRolledBack is emitted when the launch plans were restored to the versions that were active
+	// before a registration whose smoke test failed
+	EventReasonLaunchPlansRolledBack = "LaunchPlansRolledBack"
+)
+
+// Reasons of the Events emitted on a FlyteProject
+const (
+	// EventReasonProjectCreated is emitted when the project was created in flyte
+	EventReasonProjectCreated = "ProjectCreated"
+	// EventReasonProjectUpdated is emitted when the project in flyte was updated to match the spec
+	EventReasonProjectUpdated = "ProjectUpdated"
+	// EventReasonProjectAdopted is emitted when the project already existed in flyte before the FlyteProject
+	EventReasonProjectAdopted = "ProjectAdopted"
+	// EventReasonProjectSyncFailed is emitted when the project could not be created or updated in flyte
+	EventReasonProjectSyncFailed = v1.ReasonProjectSyncFailed
+)
+
+// Reasons of the Events emitted on a FlyteProjectDomainAttributes
+const (
+	// EventReasonAttributesApplied is emitted when attributes were updated in flyte to match the spec
+	EventReasonAttributesApplied = v1.ReasonAttributesApplied
+	// EventReasonAttributesDeleted is emitted when attributes removed from the spec were deleted from flyte
+	EventReasonAttributesDeleted = "AttributesDeleted"
+	// EventReasonAttributesDrifted is emitted when applied attributes were changed in flyte outside of the operator
+	EventReasonAttributesDrifted = "AttributesDrifted"
+	// EventReasonAttributesSyncFailed is emitted when the attributes could not be read, updated or deleted in flyte
+	EventReasonAttributesSyncFailed = v1.ReasonAttributesSyncFailed
+)
+
+// maxEventMessageLength caps the message of an Event, the API server rejects Events with longer messages and the
+// flytectl output of a failed registration can be much longer
+const maxEventMessageLength = 1024
+
+// truncateMessage shortens a message to maxEventMessageLength bytes, marking that it was truncated
+func truncateMessage(message string) string {
+	if len(message) <= maxEventMessageLength {
+		return message
+	}
+
+	const marker = "... (truncated)"
+	return message[:maxEventMessageLength-len(marker)] + marker
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
+)
+
+// FlyteProjectReconciler reconciles a FlyteProject object
+type FlyteProjectReconciler struct {
+	K8sClient K8sClient
+	// A reader for Secrets, which reads them from the API server rather than the cache of the manager
+	APIReader client.Reader
+	Scheme    *runtime.Scheme
+	// A configuration for the controller
+	Config internal.Config
+	// A client for interacting with the flyte.backend cluster
+	FlyteAdminClient flyte.Client
+	// A recorder for the Events emitted on the FlyteProjects
+	Recorder record.EventRecorder
+}
+
+// NewFlyteProjectReconciler returns a new FlyteProjectReconciler instance, which manages the projects with the given
+// flyte client so that it shares the rate limit of the flyte admin endpoint with the registrations
+func NewFlyteProjectReconciler(config internal.Config, k8sClient client.Client, apiReader client.Reader, scheme *runtime.Scheme, recorder record.EventRecorder, flyteClient flyte.Client) *FlyteProjectReconciler {
+	return &FlyteProjectReconciler{
+		K8sClient:        k8sClient,
+		APIReader:        apiReader,
+		Scheme:           scheme,
+		Config:           config,
+		FlyteAdminClient: flyteClient,
+		Recorder:         recorder,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager, with the same workers and back-off as the registrations
+func (r *FlyteProjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1.FlyteProject{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.MaxConcurrentReconciles,
+			RateLimiter:             rateLimiter(r.Config),
+		}).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups=flyte.backend,resources=flyteprojects,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=flyte.backend,resources=flyteprojects/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=flyte.backend,resources=flyteprojects/finalizers,verbs=update
+
+// Reconcile creates the project of a FlyteProject in flyte, or updates its name, description and labels when they do
+// not match the spec. The project is compared again every sync interval, so that changes made in flyte are reverted.
+// A project that was already active in flyte before the FlyteProject is adopted, it is not archived on deletion.
+func (r *FlyteProjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var project v1.FlyteProject
+	if err := r.K8sClient.Get(ctx, req.NamespacedName, &project); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !project.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, &project)
+	}
+
+	// The project is archived in flyte before the FlyteProject is removed
+	if controllerutil.AddFinalizer(&project, v1.Finalizer) {
+		if err := r.K8sClient.Update(ctx, &project); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update finalizers: %w", err)
+		}
+	}
+
+	flyteAuth, err := resolveFlyteAuth(ctx, r.APIReader, r.Config, clusterFlyteSecretRef(project.Spec.Flyte))
+	if err != nil {
+		return r.failReconcile(ctx, &project, v1.ReasonCredentialsUnavailable, err)
+	}
+
+	desired := desiredProject(&project)
+	current, err := r.FlyteAdminClient.GetProject(ctx, desired.ID, flyteAuth)
+	if err != nil && !errors.Is(err, flyte.ErrProjectNotFound) {
+		return r.failReconcile(ctx, &project, v1.ReasonProjectSyncFailed, fmt.Errorf("failed to get project: %w", err))
+	}
+	found := err == nil
+
+	// Adoption is decided once, from the first read of the project. A project that is active in flyte then was created
+	// outside of the operator. Otherwise the operator creates the project, or reactivates it, and records that on the
+	// status before changing anything in flyte, so that a retry after a failed status update does not adopt it.
+	undecided := !project.Status.Adopted && !project.Status.Created && project.Status.LastSyncTime == nil
+	adopted := undecided && found && current.State != flyte.ProjectStateArchived
+	if adopted {
+		project.Status.Adopted = true
+		r.Recorder.Eventf(&project, corev1.EventTypeNormal, EventReasonProjectAdopted, "Adopted project %s, it already exists in flyte", desired.ID)
+	} else if undecided {
+		project.Status.Created = true
+		if err := r.K8sClient.Status().Update(ctx, &project); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	// The project is modified when it is created or updated to match the spec
+	modified := true
+	switch {
+	case !found:
+		if err := r.FlyteAdminClient.CreateProject(ctx, desired, flyteAuth); err != nil {
+			return r.failReconcile(ctx, &project, v1.ReasonProjectSyncFailed, err)
+		}
+		r.Recorder.Eventf(&project, corev1.EventTypeNormal, EventReasonProjectCreated, "Created project %s in flyte", desired.ID)
+	case !projectMatches(current, desired):
+		if err := r.FlyteAdminClient.UpdateProject(ctx, desired, flyteAuth); err != nil {
+			return r.failReconcile(ctx, &project, v1.ReasonProjectSyncFailed, err)
+		}
+		r.Recorder.Eventf(&project, corev1.EventTypeNormal, EventReasonProjectUpdated, "Updated project %s in flyte to match the spec", desired.ID)
+	default:
+		modified = false
+	}
+
+	// The status is only written when something changed, so that the periodic syncs do not trigger reconciliations
+	changed := apimeta.SetStatusCondition(&project.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1.ReasonProjectSynced,
+		Message:            fmt.Sprintf("project %s matches the spec", desired.ID),
+		ObservedGeneration: project.Generation,
+	})
+	if modified {
+		now := metav1.Now()
+		project.Status.LastSyncTime = &now
+	}
+	if changed || modified || adopted || project.Status.ObservedGeneration != project.Generation || project.Status.LastError != "" {
+		project.Status.ObservedGeneration = project.Generation
+		project.Status.LastError = ""
+		if err := r.K8sClient.Status().Update(ctx, &project); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: r.Config.ProjectSyncInterval}, nil
+}
+
+// reconcileDelete archives the project in flyte, flyte does not delete projects, and then removes the finalizer so that
+// the FlyteProject can be deleted. An adopted project was not created by the operator, it is left active.
+func (r *FlyteProjectReconciler) reconcileDelete(ctx context.Context, project *v1.FlyteProject) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(project, v1.Finalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	if project.Status.Adopted {
+		log.Log.Info("leaving adopted project active", "name", project.Name)
+		return ctrl.Result{}, r.removeFinalizer(ctx, project)
+	}
+
+	flyteAuth, err := resolveFlyteAuth(ctx, r.APIReader, r.Config, clusterFlyteSecretRef(project.Spec.Flyte))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A project that was never created has nothing to archive
+	_, err = r.FlyteAdminClient.GetProject(ctx, project.Name, flyteAuth)
+	switch {
+	case errors.Is(err, flyte.ErrProjectNotFound):
+	case err != nil:
+		return ctrl.Result{}, fmt.Errorf("failed to get project: %w", err)
+	default:
+		if err := r.FlyteAdminClient.ArchiveProject(ctx, project.Name, flyteAuth); err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Log.Info("archived project", "name", project.Name)
+	}
+
+	return ctrl.Result{}, r.removeFinalizer(ctx, project)
+}
+
+// removeFinalizer removes the finalizer of the FlyteProject so that it can be deleted
+func (r *FlyteProjectReconciler) removeFinalizer(ctx context.Context, project *v1.FlyteProject) error {
+	controllerutil.RemoveFinalizer(project, v1.Finalizer)
+	if err := r.K8sClient.Update(ctx, project); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	return nil
+}
+
+// failReconcile records a failed reconciliation on the status of the FlyteProject and in a Warning Event, and returns
+// the original error so that the request is retried with back-off
+func (r *FlyteProjectReconciler) failReconcile(ctx context.Context, project *v1.FlyteProject, reason string, err error) (ctrl.Result, error) {
+	apimeta.SetStatusCondition(&project.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionTypeReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		ObservedGeneration: project.Generation,
+	})
+	project.Status.ObservedGeneration = project.Generation
+	project.Status.LastError = err.Error()
+	r.Recorder.Event(project, corev1.EventTypeWarning, reason, truncateMessage(err.Error()))
+
+	if statusErr := r.K8sClient.Status().Update(ctx, project); statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "failed to update status", "name", project.Name)
+	}
+
+	return ctrl.Result{}, err
+}
+
+// desiredProject returns the project in flyte described by a FlyteProject, whose name is the id of the project
+func desiredProject(project *v1.FlyteProject) flyte.Project {
+	name := project.Spec.DisplayName
+	if name == "" {
+		name = project.Name
+	}
+
+	return flyte.Project{
+		ID:          project.Name,
+		Name:        name,
+		Description: project.Spec.Description,
+		Labels:      project.Spec.Labels,
+	}
+}
+
+// projectMatches returns true when a project in flyte is active and has the name, description and labels of the
+// desired project
+func projectMatches(current flyte.Project, desired flyte.Project) bool {
+	return current.State != flyte.ProjectStateArchived &&
+		current.Name == desired.Name &&
+		current.Description == desired.Description &&
+		maps.Equal(current.Labels, desired.Labels)
+}
@@ -0,0 +1,476 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/controller/mocks"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
+	fMocks "github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte/mocks"
+)
+
+func TestReconcileProject(t *testing.T) {
+	// SHARED INPUTS
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "test-project"},
+	}
+
+	config := internal.Config{FlyteClientID: "test-client-id", FlyteAdminEndpoint: "test-endpoint", ProjectSyncInterval: 10 * time.Minute}
+	flyteAuth := flyte.Auth{
+		AdminEndpoint:      "test-endpoint",
+		ClientID:           "test-client-id",
+		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	}
+
+	spec := v1.FlyteProjectSpec{
+		DisplayName: "Test project",
+		Description: "test",
+		Labels:      map[string]string{"team": "data"},
+	}
+	desired := flyte.Project{
+		ID:          "test-project",
+		Name:        "Test project",
+		Description: "test",
+		Labels:      map[string]string{"team": "data"},
+	}
+
+	// syncedStatus is the status of a FlyteProject whose project matches the spec
+	lastSyncTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	syncedStatus := v1.FlyteProjectStatus{
+		ObservedGeneration: 1,
+		LastSyncTime:       &lastSyncTime,
+		Conditions: []metav1.Condition{
+			{Type: v1.ConditionTypeReady, Status: metav1.ConditionTrue, Reason: v1.ReasonProjectSynced,
+				Message: "project test-project matches the spec", ObservedGeneration: 1},
+		},
+	}
+
+	// getProject mocks reading a FlyteProject with the spec, the finalizer and the given status
+	getProject := func(mockK8sClient *mocks.K8sClient, status v1.FlyteProjectStatus) {
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, mock.AnythingOfType("*v1.FlyteProject")).
+			Run(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) {
+				project := obj.(*v1.FlyteProject)
+				project.Name = req.Name
+				project.Generation = 1
+				project.Finalizers = []string{v1.Finalizer}
+				project.Spec = spec
+				project.Status = status
+			}).Return(nil).Once()
+	}
+
+	t.Run("success case: missing project is created", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getProject(mockK8sClient, v1.FlyteProjectStatus{})
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetProject(mock.Anything, "test-project", flyteAuth).Return(flyte.Project{}, flyte.ErrProjectNotFound).Once()
+		mockFlyteAdminClient.EXPECT().CreateProject(mock.Anything, desired, flyteAuth).Return(nil).Once()
+
+		var status v1.FlyteProjectStatus
+		mockStatusWriter := mocks.NewSubResourceWriter(t)
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Twice()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteProject).Status
+			}).Return(nil).Twice()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteProjectReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, 10*time.Minute, result.RequeueAfter)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+		assert.Equal(t, int64(1), status.ObservedGeneration)
+		assert.NotNil(t, status.LastSyncTime)
+		assert.False(t, status.Adopted)
+		assert.True(t, status.Created)
+		assert.Equal(t, "Normal ProjectCreated Created project test-project in flyte", <-recorder.Events)
+	})
+
+	t.Run("success case: existing project is adopted", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getProject(mockK8sClient, v1.FlyteProjectStatus{})
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetProject(mock.Anything, "test-project", flyteAuth).Return(desired, nil).Once()
+
+		var status v1.FlyteProjectStatus
+		mockStatusWriter := mocks.NewSubResourceWriter(t)
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteProject).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteProjectReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.True(t, status.Adopted)
+		assert.False(t, status.Created)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+		assert.Nil(t, status.LastSyncTime)
+		assert.Equal(t, "Normal ProjectAdopted Adopted project test-project, it already exists in flyte", <-recorder.Events)
+	})
+
+	t.Run("success case: created project is not adopted after the status update fails", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getProject(mockK8sClient, v1.FlyteProjectStatus{})
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetProject(mock.Anything, "test-project", flyteAuth).Return(flyte.Project{}, flyte.ErrProjectNotFound).Once()
+		mockFlyteAdminClient.EXPECT().CreateProject(mock.Anything, desired, flyteAuth).Return(nil).Once()
+
+		// The decision to create the project is recorded, then the status update after the project is created fails
+		var status v1.FlyteProjectStatus
+		mockStatusWriter := mocks.NewSubResourceWriter(t)
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Twice()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = *obj.(*v1.FlyteProject).Status.DeepCopy()
+			}).Return(nil).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(errors.New("test error")).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteProjectReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		require.ErrorContains(t, err, "failed to update status")
+		assert.True(t, status.Created)
+		assert.Equal(t, "Normal ProjectCreated Created project test-project in flyte", <-recorder.Events)
+
+		// MOCK BEHAVIOUR
+		// The retry finds the project the operator created active in flyte
+		getProject(mockK8sClient, status)
+		mockFlyteAdminClient.EXPECT().GetProject(mock.Anything, "test-project", flyteAuth).Return(desired, nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteProject).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		_, err = reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.False(t, status.Adopted)
+		assert.True(t, status.Created)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("success case: archived project is not adopted", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getProject(mockK8sClient, v1.FlyteProjectStatus{})
+
+		archived := desired
+		archived.State = flyte.ProjectStateArchived
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetProject(mock.Anything, "test-project", flyteAuth).Return(archived, nil).Once()
+		mockFlyteAdminClient.EXPECT().UpdateProject(mock.Anything, desired, flyteAuth).Return(nil).Once()
+
+		var status v1.FlyteProjectStatus
+		mockStatusWriter := mocks.NewSubResourceWriter(t)
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Twice()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteProject).Status
+			}).Return(nil).Twice()
+
+		// EXECUTION
+		reconciler := &FlyteProjectReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.False(t, status.Adopted)
+		assert.True(t, status.Created)
+		assert.NotNil(t, status.LastSyncTime)
+	})
+
+	t.Run("success case: changed project is updated", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getProject(mockK8sClient, syncedStatus)
+
+		changed := desired
+		changed.Labels = map[string]string{"team": "other"}
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetProject(mock.Anything, "test-project", flyteAuth).Return(changed, nil).Once()
+		mockFlyteAdminClient.EXPECT().UpdateProject(mock.Anything, desired, flyteAuth).Return(nil).Once()
+
+		mockStatusWriter := mocks.NewSubResourceWriter(t)
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteProjectReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, "Normal ProjectUpdated Updated project test-project in flyte to match the spec", <-recorder.Events)
+	})
+
+	t.Run("success case: matching project leaves the status alone", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getProject(mockK8sClient, syncedStatus)
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetProject(mock.Anything, "test-project", flyteAuth).Return(desired, nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteProjectReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, 10*time.Minute, result.RequeueAfter)
+	})
+
+	t.Run("failure case: project cannot be created", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getProject(mockK8sClient, v1.FlyteProjectStatus{})
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetProject(mock.Anything, "test-project", flyteAuth).Return(flyte.Project{}, flyte.ErrProjectNotFound).Once()
+		mockFlyteAdminClient.EXPECT().CreateProject(mock.Anything, desired, flyteAuth).Return(errors.New("test error")).Once()
+
+		var status v1.FlyteProjectStatus
+		mockStatusWriter := mocks.NewSubResourceWriter(t)
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Twice()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteProject).Status
+			}).Return(nil).Twice()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteProjectReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "test error")
+		condition := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeReady)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, v1.ReasonProjectSyncFailed, condition.Reason)
+		assert.Equal(t, "test error", status.LastError)
+		assert.Equal(t, "Warning ProjectSyncFailed test error", <-recorder.Events)
+	})
+
+	t.Run("success case: deletion archives the project and removes the finalizer", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		deletionTimestamp := metav1.Now()
+		mockK8sClient := mocks.NewK8sClient(t)
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, mock.AnythingOfType("*v1.FlyteProject")).
+			Run(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) {
+				project := obj.(*v1.FlyteProject)
+				project.Name = req.Name
+				project.DeletionTimestamp = &deletionTimestamp
+				project.Finalizers = []string{v1.Finalizer}
+			}).Return(nil).Once()
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetProject(mock.Anything, "test-project", flyteAuth).Return(desired, nil).Once()
+		mockFlyteAdminClient.EXPECT().ArchiveProject(mock.Anything, "test-project", flyteAuth).Return(nil).Once()
+
+		var removed bool
+		mockK8sClient.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.UpdateOption) {
+				removed = !controllerutil.ContainsFinalizer(obj, v1.Finalizer)
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteProjectReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.True(t, removed)
+	})
+
+	t.Run("success case: deletion leaves an adopted project active", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		deletionTimestamp := metav1.Now()
+		mockK8sClient := mocks.NewK8sClient(t)
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, mock.AnythingOfType("*v1.FlyteProject")).
+			Run(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) {
+				project := obj.(*v1.FlyteProject)
+				project.Name = req.Name
+				project.DeletionTimestamp = &deletionTimestamp
+				project.Finalizers = []string{v1.Finalizer}
+				project.Status.Adopted = true
+			}).Return(nil).Once()
+
+		var removed bool
+		mockK8sClient.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.UpdateOption) {
+				removed = !controllerutil.ContainsFinalizer(obj, v1.Finalizer)
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteProjectReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			FlyteAdminClient: fMocks.NewClient(t),
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.True(t, removed)
+	})
+
+	t.Run("success case: credentials from a secret in another namespace", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, mock.AnythingOfType("*v1.FlyteProject")).
+			Run(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) {
+				project := obj.(*v1.FlyteProject)
+				project.Name = req.Name
+				project.Generation = 1
+				project.Finalizers = []string{v1.Finalizer}
+				project.Spec = spec
+				project.Spec.Flyte = &v1.ClusterFlyteSpec{CredentialsSecretRef: &corev1.SecretReference{Namespace: "team", Name: "flyte-client"}}
+				project.Status = syncedStatus
+			}).Return(nil).Once()
+
+		// Secrets are read from the API server rather than the cache
+		mockAPIReader := mocks.NewK8sClient(t)
+		mockAPIReader.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: "team", Name: "flyte-client"}, mock.AnythingOfType("*v1.Secret")).
+			Run(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) {
+				obj.(*corev1.Secret).Data = map[string][]byte{"clientId": []byte("team-client-id"), "clientSecret": []byte("team-client-secret")}
+			}).Return(nil).Once()
+
+		teamAuth := flyte.Auth{
+			AdminEndpoint:      "test-endpoint",
+			ClientID:           "team-client-id",
+			ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+			ClientSecret:       "team-client-secret",
+		}
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetProject(mock.Anything, "test-project", teamAuth).Return(desired, nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteProjectReconciler{
+			K8sClient:        mockK8sClient,
+			APIReader:        mockAPIReader,
+			Recorder:         record.NewFakeRecorder(10),
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+}
+
+func TestProjectMatches(t *testing.T) {
+	desired := flyte.Project{ID: "test-project", Name: "Test project", Description: "test"}
+
+	assert.True(t, projectMatches(flyte.Project{ID: "test-project", Name: "Test project", Description: "test", Labels: map[string]string{}}, desired))
+	assert.False(t, projectMatches(flyte.Project{ID: "test-project", Name: "Other", Description: "test"}, desired))
+	assert.False(t, projectMatches(flyte.Project{ID: "test-project", Name: "Test project", Description: "test", State: flyte.ProjectStateArchived}, desired))
+}
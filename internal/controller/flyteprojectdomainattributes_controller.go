@@ -0,0 +1,314 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
+)
+
+// FlyteProjectDomainAttributesReconciler reconciles a FlyteProjectDomainAttributes object
+type FlyteProjectDomainAttributesReconciler struct {
+	K8sClient K8sClient
+	// A reader for Secrets, which reads them from the API server rather than the cache of the manager
+	APIReader client.Reader
+	Scheme    *runtime.Scheme
+	// A configuration for the controller
+	Config internal.Config
+	// A client for interacting with the flyte.backend cluster
+	FlyteAdminClient flyte.Client
+	// A recorder for the Events emitted on the FlyteProjectDomainAttributes
+	Recorder record.EventRecorder
+}
+
+// NewFlyteProjectDomainAttributesReconciler returns a new FlyteProjectDomainAttributesReconciler instance, which
+// manages the attributes with the given flyte client so that it shares the rate limit of the flyte admin endpoint
+func NewFlyteProjectDomainAttributesReconciler(config internal.Config, k8sClient client.Client, apiReader client.Reader, scheme *runtime.Scheme, recorder record.EventRecorder, flyteClient flyte.Client) *FlyteProjectDomainAttributesReconciler {
+	return &FlyteProjectDomainAttributesReconciler{
+		K8sClient:        k8sClient,
+		APIReader:        apiReader,
+		Scheme:           scheme,
+		Config:           config,
+		FlyteAdminClient: flyteClient,
+		Recorder:         recorder,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager, with the same workers and back-off as the registrations
+func (r *FlyteProjectDomainAttributesReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1.FlyteProjectDomainAttributes{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.MaxConcurrentReconciles,
+			RateLimiter:             rateLimiter(r.Config),
+		}).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups=flyte.backend,resources=flyteprojectdomainattributes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=flyte.backend,resources=flyteprojectdomainattributes/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=flyte.backend,resources=flyteprojectdomainattributes/finalizers,verbs=update
+
+// Reconcile updates the matchable attributes of a project and domain in flyte that do not match the spec, and deletes
+// the attributes that were removed from the spec. The attributes are compared again every sync interval, and the
+// applied attributes that were changed in flyte are reported as drift and reverted.
+func (r *FlyteProjectDomainAttributesReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var attributes v1.FlyteProjectDomainAttributes
+	if err := r.K8sClient.Get(ctx, req.NamespacedName, &attributes); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !attributes.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, &attributes)
+	}
+
+	// The attributes are deleted from flyte before the FlyteProjectDomainAttributes is removed
+	if controllerutil.AddFinalizer(&attributes, v1.Finalizer) {
+		if err := r.K8sClient.Update(ctx, &attributes); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update finalizers: %w", err)
+		}
+	}
+
+	flyteAuth, err := resolveFlyteAuth(ctx, r.APIReader, r.Config, clusterFlyteSecretRef(attributes.Spec.Flyte))
+	if err != nil {
+		return r.failReconcile(ctx, &attributes, v1.ReasonCredentialsUnavailable, err)
+	}
+
+	spec := attributes.Spec
+	desired := flyteAttributes(spec.Project, spec.Domain, &spec.MatchableAttributes)
+	applied := flyteAttributes(spec.Project, spec.Domain, attributes.Status.Applied)
+
+	current, err := r.FlyteAdminClient.GetMatchableAttributes(ctx, spec.Project, spec.Domain, flyteAuth)
+	if err != nil {
+		return r.failReconcile(ctx, &attributes, v1.ReasonAttributesSyncFailed, err)
+	}
+
+	// The attributes that differ from the spec although they were applied as they are in the spec were changed in flyte
+	var changed, drifted, removed []flyte.MatchableResource
+	for _, matchable := range flyte.MatchableResources {
+		want := desired.Attributes(matchable)
+		switch {
+		case want == nil && applied.Attributes(matchable) != nil:
+			removed = append(removed, matchable)
+		case want == nil || reflect.DeepEqual(current.Attributes(matchable), want):
+		case reflect.DeepEqual(applied.Attributes(matchable), want):
+			drifted = append(drifted, matchable)
+			changed = append(changed, matchable)
+		default:
+			changed = append(changed, matchable)
+		}
+	}
+
+	if len(drifted) > 0 {
+		now := metav1.Now()
+		attributes.Status.LastDriftTime = &now
+		r.Recorder.Eventf(&attributes, corev1.EventTypeWarning, EventReasonAttributesDrifted,
+			"%s of %s/%s were changed in flyte, reverting them to the spec", resourceList(drifted), spec.Project, spec.Domain)
+	}
+
+	if len(changed) > 0 {
+		if err := r.FlyteAdminClient.UpdateMatchableAttributes(ctx, desired.Select(changed), flyteAuth); err != nil {
+			return r.failReconcile(ctx, &attributes, v1.ReasonAttributesSyncFailed, err)
+		}
+		r.Recorder.Eventf(&attributes, corev1.EventTypeNormal, EventReasonAttributesApplied,
+			"Applied %s to %s/%s", resourceList(changed), spec.Project, spec.Domain)
+	}
+
+	if len(removed) > 0 {
+		if err := r.FlyteAdminClient.DeleteMatchableAttributes(ctx, spec.Project, spec.Domain, removed, flyteAuth); err != nil {
+			return r.failReconcile(ctx, &attributes, v1.ReasonAttributesSyncFailed, err)
+		}
+		r.Recorder.Eventf(&attributes, corev1.EventTypeNormal, EventReasonAttributesDeleted,
+			"Deleted %s from %s/%s", resourceList(removed), spec.Project, spec.Domain)
+	}
+
+	// The status is only written when something changed, so that the periodic syncs do not trigger reconciliations
+	status := &attributes.Status
+	updated := apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               v1.ConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             v1.ReasonAttributesApplied,
+		Message:            fmt.Sprintf("the attributes of %s/%s match the spec", spec.Project, spec.Domain),
+		ObservedGeneration: attributes.Generation,
+	})
+	// The attributes are modified when they are updated or deleted in flyte to match the spec
+	modified := len(changed) > 0 || len(removed) > 0
+	if modified {
+		now := metav1.Now()
+		status.LastSyncTime = &now
+	}
+	if !equality.Semantic.DeepEqual(status.Applied, &spec.MatchableAttributes) {
+		status.Applied = spec.MatchableAttributes.DeepCopy()
+		updated = true
+	}
+	if updated || modified || status.ObservedGeneration != attributes.Generation || status.LastError != "" {
+		status.ObservedGeneration = attributes.Generation
+		status.LastError = ""
+		if err := r.K8sClient.Status().Update(ctx, &attributes); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: r.Config.ProjectSyncInterval}, nil
+}
+
+// reconcileDelete deletes the applied attributes from flyte, so that the project and domain fall back to the defaults
+// of flyte admin, and then removes the finalizer so that the FlyteProjectDomainAttributes can be deleted
+func (r *FlyteProjectDomainAttributesReconciler) reconcileDelete(ctx context.Context, attributes *v1.FlyteProjectDomainAttributes) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(attributes, v1.Finalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	spec := attributes.Spec
+	applied := flyteAttributes(spec.Project, spec.Domain, attributes.Status.Applied).Resources()
+	if len(applied) > 0 {
+		flyteAuth, err := resolveFlyteAuth(ctx, r.APIReader, r.Config, clusterFlyteSecretRef(spec.Flyte))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := r.FlyteAdminClient.DeleteMatchableAttributes(ctx, spec.Project, spec.Domain, applied, flyteAuth); err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Log.Info("deleted attributes", "name", attributes.Name, "project", spec.Project, "domain", spec.Domain)
+	}
+
+	controllerutil.RemoveFinalizer(attributes, v1.Finalizer)
+	if err := r.K8sClient.Update(ctx, attributes); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// failReconcile records a failed reconciliation on the status of the FlyteProjectDomainAttributes and in a Warning
+// Event, and returns the original error so that the request is retried with back-off
+func (r *FlyteProjectDomainAttributesReconciler) failReconcile(ctx context.Context, attributes *v1.FlyteProjectDomainAttributes, reason string, err error) (ctrl.Result, error) {
+	apimeta.SetStatusCondition(&attributes.Status.Conditions, metav1.Condition{
+		Type:               v1.ConditionTypeReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		ObservedGeneration: attributes.Generation,
+	})
+	attributes.Status.ObservedGeneration = attributes.Generation
+	attributes.Status.LastError = err.Error()
+	r.Recorder.Event(attributes, corev1.EventTypeWarning, reason, truncateMessage(err.Error()))
+
+	if statusErr := r.K8sClient.Status().Update(ctx, attributes); statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "failed to update status", "name", attributes.Name)
+	}
+
+	return ctrl.Result{}, err
+}
+
+// flyteAttributes returns the attributes of a project and domain in the format of flytectl, the attributes are not set
+// when they are nil
+func flyteAttributes(project string, domain string, attributes *v1.MatchableAttributes) flyte.MatchableAttributes {
+	converted := flyte.MatchableAttributes{Project: project, Domain: domain}
+	if attributes == nil {
+		return converted
+	}
+
+	if resources := attributes.TaskResources; resources != nil {
+		converted.TaskResources = &flyte.TaskResourceAttributes{
+			Defaults: taskResourceSpec(resources.Defaults),
+			Limits:   taskResourceSpec(resources.Limits),
+		}
+	}
+
+	if attributes.ExecutionQueue != nil {
+		converted.ExecutionQueue = &flyte.ExecutionQueueAttributes{Tags: slices.Clone(attributes.ExecutionQueue.Tags)}
+	}
+
+	if attributes.ExecutionClusterLabel != "" {
+		converted.ExecutionClusterLabel = &flyte.ExecutionClusterLabel{Value: attributes.ExecutionClusterLabel}
+	}
+
+	if config := attributes.WorkflowExecutionConfig; config != nil {
+		executionConfig := &flyte.WorkflowExecutionConfig{
+			MaxParallelism: config.MaxParallelism,
+			OverwriteCache: config.OverwriteCache,
+		}
+		if config.ServiceAccountName != "" {
+			executionConfig.SecurityContext = &flyte.SecurityContext{RunAs: &flyte.Identity{K8sServiceAccount: config.ServiceAccountName}}
+		}
+		if config.RawOutputDataPrefix != "" {
+			executionConfig.RawOutputDataConfig = &flyte.RawOutputDataConfig{OutputLocationPrefix: config.RawOutputDataPrefix}
+		}
+		if len(config.Labels) > 0 {
+			executionConfig.Labels = &flyte.KeyValues{Values: maps.Clone(config.Labels)}
+		}
+		if len(config.Annotations) > 0 {
+			executionConfig.Annotations = &flyte.KeyValues{Values: maps.Clone(config.Annotations)}
+		}
+		if config.Interruptible != nil {
+			executionConfig.Interruptible = &flyte.BoolValue{Value: *config.Interruptible}
+		}
+		converted.WorkflowExecutionConfig = executionConfig
+	}
+
+	return converted
+}
+
+// taskResourceSpec returns the quantities of the resources of a task in their canonical form
+func taskResourceSpec(spec v1.TaskResourceSpec) flyte.TaskResourceSpec {
+	quantity := func(q *resource.Quantity) string {
+		if q == nil {
+			return ""
+		}
+		return q.String()
+	}
+
+	return flyte.TaskResourceSpec{
+		CPU:              quantity(spec.CPU),
+		GPU:              quantity(spec.GPU),
+		Memory:           quantity(spec.Memory),
+		EphemeralStorage: quantity(spec.EphemeralStorage),
+	}
+}
+
+// resourceList joins the names of matchable resources for the messages of Events
+func resourceList(resources []flyte.MatchableResource) string {
+	names := make([]string, 0, len(resources))
+	for _, matchable := range resources {
+		names = append(names, string(matchable))
+	}
+
+	return strings.Join(names, ", ")
+}
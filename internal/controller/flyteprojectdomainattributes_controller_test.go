@@ -0,0 +1,394 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/controller/mocks"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
+	fMocks "github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte/mocks"
+)
+
+func TestReconcileProjectDomainAttributes(t *testing.T) {
+	// SHARED INPUTS
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: "test-project.development"},
+	}
+
+	config := internal.Config{FlyteClientID: "test-client-id", FlyteAdminEndpoint: "test-endpoint", ProjectSyncInterval: 10 * time.Minute}
+	flyteAuth := flyte.Auth{
+		AdminEndpoint:      "test-endpoint",
+		ClientID:           "test-client-id",
+		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	}
+
+	matchable := v1.MatchableAttributes{
+		TaskResources: &v1.TaskResourceAttributes{
+			Defaults: v1.TaskResourceSpec{CPU: quantity("500m"), Memory: quantity("1Gi")},
+		},
+		ExecutionQueue: &v1.ExecutionQueueAttributes{Tags: []string{"gpu"}},
+	}
+	spec := v1.FlyteProjectDomainAttributesSpec{Project: "test-project", Domain: "development", MatchableAttributes: matchable}
+
+	taskResources := &flyte.TaskResourceAttributes{Defaults: flyte.TaskResourceSpec{CPU: "500m", Memory: "1Gi"}}
+	executionQueue := &flyte.ExecutionQueueAttributes{Tags: []string{"gpu"}}
+	desired := flyte.MatchableAttributes{
+		Project:        "test-project",
+		Domain:         "development",
+		TaskResources:  taskResources,
+		ExecutionQueue: executionQueue,
+	}
+
+	// appliedStatus is the status of a FlyteProjectDomainAttributes whose attributes were applied as they are in the spec
+	appliedStatus := v1.FlyteProjectDomainAttributesStatus{
+		ObservedGeneration: 1,
+		Applied:            matchable.DeepCopy(),
+		Conditions: []metav1.Condition{
+			{Type: v1.ConditionTypeReady, Status: metav1.ConditionTrue, Reason: v1.ReasonAttributesApplied,
+				Message: "the attributes of test-project/development match the spec", ObservedGeneration: 1},
+		},
+	}
+
+	// getAttributes mocks reading a FlyteProjectDomainAttributes with the spec, the finalizer and the given status
+	getAttributes := func(mockK8sClient *mocks.K8sClient, spec v1.FlyteProjectDomainAttributesSpec, status v1.FlyteProjectDomainAttributesStatus) {
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, mock.AnythingOfType("*v1.FlyteProjectDomainAttributes")).
+			Run(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) {
+				attributes := obj.(*v1.FlyteProjectDomainAttributes)
+				attributes.Name = req.Name
+				attributes.Generation = 1
+				attributes.Finalizers = []string{v1.Finalizer}
+				attributes.Spec = spec
+				// The conditions of the shared statuses must not be changed by the reconciliations
+				attributes.Status = *status.DeepCopy()
+			}).Return(nil).Once()
+	}
+
+	t.Run("success case: missing attributes are applied", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getAttributes(mockK8sClient, spec, v1.FlyteProjectDomainAttributesStatus{})
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetMatchableAttributes(mock.Anything, "test-project", "development", flyteAuth).
+			Return(flyte.MatchableAttributes{Project: "test-project", Domain: "development"}, nil).Once()
+		mockFlyteAdminClient.EXPECT().UpdateMatchableAttributes(mock.Anything, desired, flyteAuth).Return(nil).Once()
+
+		var status v1.FlyteProjectDomainAttributesStatus
+		mockStatusWriter := mocks.NewSubResourceWriter(t)
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteProjectDomainAttributes).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteProjectDomainAttributesReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, 10*time.Minute, result.RequeueAfter)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+		assert.Equal(t, &matchable, status.Applied)
+		assert.NotNil(t, status.LastSyncTime)
+		assert.Nil(t, status.LastDriftTime)
+		assert.Equal(t, "Normal AttributesApplied Applied task-resource-attribute, execution-queue-attribute to test-project/development", <-recorder.Events)
+	})
+
+	t.Run("success case: drifted attributes are reverted", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getAttributes(mockK8sClient, spec, appliedStatus)
+
+		drifted := desired
+		drifted.ExecutionQueue = &flyte.ExecutionQueueAttributes{Tags: []string{"cpu"}}
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetMatchableAttributes(mock.Anything, "test-project", "development", flyteAuth).Return(drifted, nil).Once()
+		mockFlyteAdminClient.EXPECT().UpdateMatchableAttributes(mock.Anything, flyte.MatchableAttributes{
+			Project:        "test-project",
+			Domain:         "development",
+			ExecutionQueue: executionQueue,
+		}, flyteAuth).Return(nil).Once()
+
+		var status v1.FlyteProjectDomainAttributesStatus
+		mockStatusWriter := mocks.NewSubResourceWriter(t)
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteProjectDomainAttributes).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteProjectDomainAttributesReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.NotNil(t, status.LastDriftTime)
+		assert.Equal(t, "Warning AttributesDrifted execution-queue-attribute of test-project/development were changed in flyte, reverting them to the spec", <-recorder.Events)
+		assert.Equal(t, "Normal AttributesApplied Applied execution-queue-attribute to test-project/development", <-recorder.Events)
+	})
+
+	t.Run("success case: matching attributes leave the status alone", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getAttributes(mockK8sClient, spec, appliedStatus)
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetMatchableAttributes(mock.Anything, "test-project", "development", flyteAuth).Return(desired, nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteProjectDomainAttributesReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, 10*time.Minute, result.RequeueAfter)
+	})
+
+	t.Run("success case: attributes removed from the spec are deleted", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		removedSpec := spec
+		removedSpec.ExecutionQueue = nil
+
+		mockK8sClient := mocks.NewK8sClient(t)
+		getAttributes(mockK8sClient, removedSpec, appliedStatus)
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetMatchableAttributes(mock.Anything, "test-project", "development", flyteAuth).Return(desired, nil).Once()
+		mockFlyteAdminClient.EXPECT().DeleteMatchableAttributes(mock.Anything, "test-project", "development",
+			[]flyte.MatchableResource{flyte.MatchableResourceExecutionQueue}, flyteAuth).Return(nil).Once()
+
+		var status v1.FlyteProjectDomainAttributesStatus
+		mockStatusWriter := mocks.NewSubResourceWriter(t)
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteProjectDomainAttributes).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteProjectDomainAttributesReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		require.NotNil(t, status.Applied)
+		assert.Nil(t, status.Applied.ExecutionQueue)
+		assert.Equal(t, "Normal AttributesDeleted Deleted execution-queue-attribute from test-project/development", <-recorder.Events)
+	})
+
+	t.Run("failure case: attributes cannot be read", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		getAttributes(mockK8sClient, spec, appliedStatus)
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetMatchableAttributes(mock.Anything, "test-project", "development", flyteAuth).
+			Return(flyte.MatchableAttributes{}, errors.New("test error")).Once()
+
+		var status v1.FlyteProjectDomainAttributesStatus
+		mockStatusWriter := mocks.NewSubResourceWriter(t)
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteProjectDomainAttributes).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteProjectDomainAttributesReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "test error")
+		condition := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeReady)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, v1.ReasonAttributesSyncFailed, condition.Reason)
+		assert.Equal(t, "test error", status.LastError)
+		assert.Equal(t, "Warning AttributesSyncFailed test error", <-recorder.Events)
+	})
+
+	t.Run("success case: deletion deletes the applied attributes and removes the finalizer", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		deletionTimestamp := metav1.Now()
+		mockK8sClient := mocks.NewK8sClient(t)
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, mock.AnythingOfType("*v1.FlyteProjectDomainAttributes")).
+			Run(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) {
+				attributes := obj.(*v1.FlyteProjectDomainAttributes)
+				attributes.Name = req.Name
+				attributes.DeletionTimestamp = &deletionTimestamp
+				attributes.Finalizers = []string{v1.Finalizer}
+				attributes.Spec = spec
+				attributes.Status = appliedStatus
+			}).Return(nil).Once()
+
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().DeleteMatchableAttributes(mock.Anything, "test-project", "development",
+			[]flyte.MatchableResource{flyte.MatchableResourceTaskResource, flyte.MatchableResourceExecutionQueue}, flyteAuth).Return(nil).Once()
+
+		var removed bool
+		mockK8sClient.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.UpdateOption) {
+				removed = !controllerutil.ContainsFinalizer(obj, v1.Finalizer)
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteProjectDomainAttributesReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.True(t, removed)
+	})
+	t.Run("success case: credentials from a secret in another namespace", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient := mocks.NewK8sClient(t)
+		withCredentials := spec
+		withCredentials.Flyte = &v1.ClusterFlyteSpec{CredentialsSecretRef: &corev1.SecretReference{Namespace: "team", Name: "flyte-client"}}
+		getAttributes(mockK8sClient, withCredentials, appliedStatus)
+
+		// Secrets are read from the API server rather than the cache
+		mockAPIReader := mocks.NewK8sClient(t)
+		mockAPIReader.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: "team", Name: "flyte-client"}, mock.AnythingOfType("*v1.Secret")).
+			Run(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) {
+				obj.(*corev1.Secret).Data = map[string][]byte{"clientId": []byte("team-client-id"), "clientSecret": []byte("team-client-secret")}
+			}).Return(nil).Once()
+
+		teamAuth := flyte.Auth{
+			AdminEndpoint:      "test-endpoint",
+			ClientID:           "team-client-id",
+			ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+			ClientSecret:       "team-client-secret",
+		}
+		mockFlyteAdminClient := fMocks.NewClient(t)
+		mockFlyteAdminClient.EXPECT().GetMatchableAttributes(mock.Anything, "test-project", "development", teamAuth).Return(desired, nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteProjectDomainAttributesReconciler{
+			K8sClient:        mockK8sClient,
+			APIReader:        mockAPIReader,
+			Recorder:         record.NewFakeRecorder(10),
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           config,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+}
+
+func TestFlyteAttributes(t *testing.T) {
+	interruptible := false
+	attributes := flyteAttributes("test-project", "development", &v1.MatchableAttributes{
+		TaskResources: &v1.TaskResourceAttributes{
+			Limits: v1.TaskResourceSpec{CPU: quantity("2000m"), EphemeralStorage: quantity("10Gi")},
+		},
+		ExecutionClusterLabel: "cluster-a",
+		WorkflowExecutionConfig: &v1.WorkflowExecutionConfig{
+			MaxParallelism:     5,
+			ServiceAccountName: "workflows",
+			Labels:             map[string]string{"team": "data"},
+			Interruptible:      &interruptible,
+		},
+	})
+
+	assert.Equal(t, flyte.MatchableAttributes{
+		Project:               "test-project",
+		Domain:                "development",
+		TaskResources:         &flyte.TaskResourceAttributes{Limits: flyte.TaskResourceSpec{CPU: "2", EphemeralStorage: "10Gi"}},
+		ExecutionClusterLabel: &flyte.ExecutionClusterLabel{Value: "cluster-a"},
+		WorkflowExecutionConfig: &flyte.WorkflowExecutionConfig{
+			MaxParallelism:  5,
+			SecurityContext: &flyte.SecurityContext{RunAs: &flyte.Identity{K8sServiceAccount: "workflows"}},
+			Labels:          &flyte.KeyValues{Values: map[string]string{"team": "data"}},
+			Interruptible:   &flyte.BoolValue{Value: false},
+		},
+	}, attributes)
+	assert.Equal(t, []flyte.MatchableResource{
+		flyte.MatchableResourceTaskResource,
+		flyte.MatchableResourceExecutionClusterLabel,
+		flyte.MatchableResourceWorkflowExecutionConfig,
+	}, attributes.Resources())
+}
+
+// quantity returns a pointer to a parsed resource quantity
+func quantity(value string) *resource.Quantity {
+	q := resource.MustParse(value)
+	return &q
+}
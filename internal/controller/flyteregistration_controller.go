@@ -19,30 +19,71 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/command"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/digest"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/downloader"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/metrics"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/ratelimit"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/signature"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/version"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/workspace"
 )
 
+// maxRegisteredEntities caps the number of entity names of each resource type recorded on the status, so that large
+// workflow packages do not bloat the FlyteRegistration object
+const maxRegisteredEntities = 100
+
+// registrationChanged selects the changes to FlyteRegistrations that are reconciled, changes to their spec and their
+// annotations. Each attempt writes its time to the status, reconciling status updates would retry failed
+// registrations straight away instead of with the back-off of the workqueue.
+var registrationChanged = predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})
+
 // K8sClient is the interface the K8s client used for mocking
 //
 //go:generate mockery --name=K8sClient
 type K8sClient interface {
 	Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
+	List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
+	Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error
+	Status() client.SubResourceWriter
 }
 
 // FlyteRegistrationReconciler reconciles a FlyteRegistration object
 type FlyteRegistrationReconciler struct {
 	K8sClient K8sClient
+	// A reader for Secrets, which reads them from the API server rather than the cache of the manager
+	APIReader client.Reader
 	Scheme    *runtime.Scheme
 	// A configuration for the controller
 	Config internal.Config
@@ -50,11 +91,15 @@ type FlyteRegistrationReconciler struct {
 	Downloader downloader.Client
 	// A client for interacting with the flyte.backend cluster
 	FlyteAdminClient flyte.Client
+	// The workspaces the workflow packages are downloaded to
+	Workspaces *workspace.Manager
+	// A recorder for the Events emitted on the FlyteRegistrations, so their owners can follow each registration
+	Recorder record.EventRecorder
 }
 
 // NewFlyteRegistrationReconciler sets up the required dependencies for the controller
 // and returns a new FlyteRegistrationReconciler instance
-func NewFlyteRegistrationReconciler(config internal.Config, k8sClient client.Client, scheme *runtime.Scheme) (*FlyteRegistrationReconciler, error) {
+func NewFlyteRegistrationReconciler(config internal.Config, k8sClient client.Client, apiReader client.Reader, scheme *runtime.Scheme, recorder record.EventRecorder) (*FlyteRegistrationReconciler, error) {
 	ctx := context.Background()
 
 	// Set up the artifact Client
@@ -64,27 +109,51 @@ func NewFlyteRegistrationReconciler(config internal.Config, k8sClient client.Cli
 	}
 
 	// Set up the Flyte Client
-	fClient := flyte.NewClient(command.NewOSCommandExecutor())
+	fClient := flyte.NewClient(command.NewOSCommandExecutor(),
+		ratelimit.NewLimiter(config.FlyteAdminRateLimit, config.FlyteAdminRateBurst))
 
 	return &FlyteRegistrationReconciler{
 		K8sClient:        k8sClient,
+		APIReader:        apiReader,
 		Scheme:           scheme,
 		Downloader:       d,
 		FlyteAdminClient: fClient,
 		Config:           config,
+		Workspaces:       workspace.NewManager(config.WorkspaceRoot, config.WorkspaceMaxBytes),
+		Recorder:         recorder,
 	}, nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// SetupWithManager sets up the controller with the Manager. The FlyteRegistrations are reconciled by the configured
+// number of workers, and failed reconciliations are retried with the configured exponential backoff. Updates of the
+// status alone are not reconciled. The FlyteRegistrations waiting for a FlyteProject are reconciled again when it
+// changes.
 func (r *FlyteRegistrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1.FlyteRegistration{}).
+		For(&v1.FlyteRegistration{}, builder.WithPredicates(registrationChanged)).
+		Watches(&v1.FlyteProject{}, handler.EnqueueRequestsFromMapFunc(r.registrationsForProject)).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.MaxConcurrentReconciles,
+			RateLimiter:             rateLimiter(r.Config),
+		}).
 		Complete(r)
 }
 
+// rateLimiter returns the rate limiter of the work queues of the controllers. Failed reconciliations of an object are
+// retried with the configured exponential backoff, and the queue as a whole is held to the overall rate and burst of
+// the default controller-runtime rate limiter.
+func rateLimiter(config internal.Config) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(config.ReconcileBaseBackoff, config.ReconcileMaxBackoff),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
+
 //+kubebuilder:rbac:groups=flyte.backend,resources=flyteregistrations,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=flyte.backend,resources=flyteregistrations/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=flyte.backend,resources=flyteregistrations/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -101,38 +170,726 @@ func (r *FlyteRegistrationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	var flyteWorkflow v1.FlyteRegistration
 	if err := r.K8sClient.Get(ctx, req.NamespacedName, &flyteWorkflow); err != nil {
 		log.Log.Info("unable to fetch any workflows")
+		if apierrors.IsNotFound(err) {
+			metrics.SetFailed(req.NamespacedName, false)
+		}
 		// There are no workflows to reconcile
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !flyteWorkflow.DeletionTimestamp.IsZero() {
+		metrics.SetFailed(req.NamespacedName, false)
+		return r.reconcileDelete(ctx, &flyteWorkflow)
+	}
+
+	// A suspended FlyteRegistration is left alone, apart from reporting that it is suspended
+	if flyteWorkflow.Spec.Suspend {
+		return r.reconcileSuspended(ctx, &flyteWorkflow)
+	}
+
+	if err := r.resume(ctx, &flyteWorkflow); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Only FlyteRegistrations that archive their entities on deletion need a finalizer
+	if err := r.ensureFinalizer(ctx, &flyteWorkflow); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	workflowVersion := flyteWorkflow.Spec.WorkflowVersion
 	workflowDomain := flyteWorkflow.Spec.WorkflowDomain
 	workflowProject := flyteWorkflow.Spec.WorkflowProject
 	workflowPackageURI := flyteWorkflow.Spec.WorkflowPackageURI
+	if flyteWorkflow.Spec.Source != nil {
+		workflowPackageURI = downloader.PackageURI(workflowPackageURI, flyteWorkflow.Spec.Source.Type)
+	}
+
+	// Resolve the credentials referenced by the spec before downloading anything. With a version policy they are
+	// needed to list the versions of the workflow package, before deciding whether there is anything to register
+	var sourceCredentials *internal.Credentials
+	if flyteWorkflow.Spec.VersionPolicy != "" {
+		var err error
+		sourceCredentials, err = r.sourceCredentials(ctx, &flyteWorkflow)
+		if err != nil {
+			return r.failReconcile(ctx, &flyteWorkflow, v1.ConditionTypeDownloaded, v1.ReasonCredentialsUnavailable, err)
+		}
+
+		workflowVersion, err = r.resolveVersion(ctx, &flyteWorkflow, workflowPackageURI, sourceCredentials)
+		if err != nil {
+			return r.failReconcile(ctx, &flyteWorkflow, v1.ConditionTypeDownloaded, v1.ReasonVersionResolutionFailed, err)
+		}
+	} else {
+		flyteWorkflow.Status.ResolvedVersion = ""
+	}
+
+	// Skip the registration when this spec has already been registered, unless it is forced
+	hash, err := specHash(flyteWorkflow.Spec, r.Config.DownloadStrategy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	forced := reconcileRequested(&flyteWorkflow) || flyteWorkflow.Annotations[v1.ForceRegistrationAnnotation] == "true"
+	if isRegistered(&flyteWorkflow, hash, workflowVersion) && !forced {
+		// The registered version is polled while its smoke test runs
+		if smokeTestPending(&flyteWorkflow) {
+			return r.reconcileSmokeTest(ctx, &flyteWorkflow)
+		}
+
+		log.Log.Info("skipping registration, spec unchanged since the last success", "name", req.Name, "generation", flyteWorkflow.Generation)
+		// A version policy is polled, so only the skips of a spec without one are worth an Event
+		if flyteWorkflow.Spec.VersionPolicy == "" {
+			r.Recorder.Eventf(&flyteWorkflow, corev1.EventTypeNormal, EventReasonSkippedUnchanged,
+				"Version %s has already been registered, skipping", workflowVersion)
+		}
+		return r.result(&flyteWorkflow), nil
+	}
+
+	// A version that was rolled back keeps failing the same way, the last version registered successfully stays live
+	if rolledBack(&flyteWorkflow, hash, workflowVersion) && !forced {
+		log.Log.Info("skipping registration, version was rolled back", "name", req.Name, "version", workflowVersion)
+		return r.result(&flyteWorkflow), nil
+	}
+
+	targets := registrationTargets(&flyteWorkflow, workflowVersion)
+
+	// Wait for the FlyteProjects of the projects the package is registered into, their FlyteProjects being Ready
+	// reconciles the FlyteRegistration again
+	if flyteWorkflow.Spec.WaitForProject {
+		waiting, err := r.waitingForProject(ctx, targets)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if waiting != "" {
+			return r.reconcileWaiting(ctx, &flyteWorkflow, waiting)
+		}
+	}
+
+	// Record the attempt on the status, it is written back once the outcome is known
+	now := metav1.Now()
+	flyteWorkflow.Status.ObservedGeneration = flyteWorkflow.Generation
+	flyteWorkflow.Status.LastAttemptTime = &now
+	if requestedAt, ok := flyteWorkflow.Annotations[v1.ReconcileRequestedAtAnnotation]; ok {
+		flyteWorkflow.Status.LastHandledReconcileAt = requestedAt
+	}
+
+	if flyteWorkflow.Spec.VersionPolicy == "" {
+		sourceCredentials, err = r.sourceCredentials(ctx, &flyteWorkflow)
+		if err != nil {
+			return r.failReconcile(ctx, &flyteWorkflow, v1.ConditionTypeDownloaded, v1.ReasonCredentialsUnavailable, err)
+		}
+	}
+
+	flyteAuth, err := r.flyteAuth(ctx, &flyteWorkflow)
+	if err != nil {
+		return r.failReconcile(ctx, &flyteWorkflow, v1.ConditionTypeRegistered, v1.ReasonCredentialsUnavailable, err)
+	}
+
+	// Each version is downloaded once and registered into every target that uses it, a failing target does not stop
+	// the package from being registered into the others. The Verified condition is only reported in the domains that
+	// require signed packages
+	apimeta.RemoveStatusCondition(&flyteWorkflow.Status.Conditions, v1.ConditionTypeVerified)
+	outcomes := make(map[target]targetOutcome, len(targets))
+	for _, group := range groupByVersion(targets) {
+		for _, outcome := range r.registerVersion(ctx, &flyteWorkflow, workflowPackageURI, sourceCredentials, flyteAuth, group) {
+			outcomes[outcome.target] = outcome
+		}
+	}
+
+	activeLaunchPlans := flyteWorkflow.Status.LaunchPlans
+	var failure *targetError
+	var errs []error
+	var messages []string
+	statuses := make([]v1.TargetStatus, 0, len(targets))
+	var launchPlans []v1.LaunchPlanStatus
+	for _, t := range targets {
+		outcome := outcomes[t]
+		if outcome.launchPlans != nil {
+			launchPlans = append(launchPlans, outcome.launchPlans...)
+		} else {
+			launchPlans = append(launchPlans, previousLaunchPlans(&flyteWorkflow, t)...)
+		}
+
+		status := previousTargetStatus(&flyteWorkflow, t)
+		if outcome.err != nil {
+			err := outcome.err.err
+			if len(flyteWorkflow.Spec.Targets) > 0 {
+				err = fmt.Errorf("%s/%s: %w", t.project, t.domain, err)
+			}
+			if failure == nil {
+				failure = outcome.err
+			}
+			errs = append(errs, err)
+			status.Ready = false
+			status.LastError = err.Error()
+		} else {
+			messages = append(messages, outcome.message)
+			status.Ready = true
+			status.WorkflowVersion = t.version
+			status.ResolvedDigest = outcome.artifact.Digest
+			status.LastSuccessTime = &now
+			status.LastError = ""
+		}
+		statuses = append(statuses, status)
+	}
+	flyteWorkflow.Status.Targets = statuses
+	flyteWorkflow.Status.LaunchPlans = launchPlans
+
+	if failure != nil {
+		err := errors.Join(errs...)
+		if !canRollBack(&flyteWorkflow) {
+			return r.failReconcile(ctx, &flyteWorkflow, failure.conditionType, failure.reason, err)
+		}
+
+		// The launch plans of the last version registered successfully stay live while a transient failure is retried
+		// with backoff, only a version that fails the same way every time is rolled back and skipped
+		if !failure.deterministic() {
+			if rollbackErr := r.restoreLaunchPlans(ctx, &flyteWorkflow, flyteWorkflow.Status.History[0].LaunchPlans, flyteAuth); rollbackErr != nil {
+				err = fmt.Errorf("%w, failed to roll back launch plans: %w", err, rollbackErr)
+			}
+			return r.failReconcile(ctx, &flyteWorkflow, failure.conditionType, failure.reason, err)
+		}
+
+		if rollbackErr := r.rollBack(ctx, &flyteWorkflow, workflowVersion, hash, err.Error(), flyteAuth); rollbackErr != nil {
+			return r.failReconcile(ctx, &flyteWorkflow, failure.conditionType, failure.reason,
+				fmt.Errorf("%w, failed to roll back launch plans: %w", err, rollbackErr))
+		}
+		if err := r.recordFailure(ctx, &flyteWorkflow, failure.conditionType, failure.reason, err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+		return r.result(&flyteWorkflow), nil
+	}
+
+	message := strings.Join(messages, ", ")
+	setCondition(&flyteWorkflow, v1.ConditionTypeRegistered, metav1.ConditionTrue, v1.ReasonRegistrationSucceeded, message)
+	if flyteWorkflow.Spec.SmokeTest == nil {
+		setCondition(&flyteWorkflow, v1.ConditionTypeReady, metav1.ConditionTrue, v1.ReasonRegistrationSucceeded, message)
+		apimeta.RemoveStatusCondition(&flyteWorkflow.Status.Conditions, v1.ConditionTypeSmokeTested)
+		flyteWorkflow.Status.SmokeTest = nil
+	}
+
+	// The top level of the status describes the registration into the first target
+	primary := outcomes[targets[0]]
+	flyteWorkflow.Status.LastSuccessTime = &now
+	flyteWorkflow.Status.LastError = ""
+	flyteWorkflow.Status.LastRegisteredSpecHash = hash
+	flyteWorkflow.Status.ResolvedDigest = primary.artifact.Digest
+	flyteWorkflow.Status.ResolvedETag = primary.artifact.ETag
+	flyteWorkflow.Status.ResolvedVersionID = primary.artifact.VersionID
+	flyteWorkflow.Status.RegisteredEntities = registeredEntities(primary.results)
+	flyteWorkflow.Status.WorkflowVersion = workflowVersion
+	flyteWorkflow.Status.WorkflowDomain = workflowDomain
+	flyteWorkflow.Status.WorkflowProject = workflowProject
+	flyteWorkflow.Status.WorkflowPackageURI = workflowPackageURI
 
-	meta := flyte.WorkflowMetadata{
+	// A version with a smoke test is only Ready once the execution of the smoke test has succeeded
+	if flyteWorkflow.Spec.SmokeTest != nil {
+		log.Log.Info("registered workflow, launching smoke test", "name", req.Name, "version", workflowVersion, "targets", len(targets))
+		result, err := r.startSmokeTest(ctx, &flyteWorkflow, targets[0], activeLaunchPlans, flyteAuth)
+		if err != nil {
+			return result, err
+		}
+		return result, r.clearForceRegistration(ctx, &flyteWorkflow)
+	}
+	recordReadyVersion(&flyteWorkflow, v1.RegisteredVersion{
 		WorkflowVersion: workflowVersion,
-		Domain:          workflowDomain,
-		Project:         workflowProject,
+		Digest:          primary.artifact.Digest,
+		RegisteredTime:  now,
+		LaunchPlans:     launchPlans,
+	})
+
+	if err := r.K8sClient.Status().Update(ctx, &flyteWorkflow); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+	}
+	metrics.SetFailed(req.NamespacedName, false)
+
+	if err := r.clearForceRegistration(ctx, &flyteWorkflow); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.Log.Info("successfully registered workflow", "name", req.Name, "version", workflowVersion, "targets", len(targets), "project", workflowProject)
+	return r.result(&flyteWorkflow), nil
+}
+
+// registerVersion downloads a version of the workflow package into a workspace of its own, and registers it into the
+// targets that use the version. The workspace is removed with the package once it has been registered.
+func (r *FlyteRegistrationReconciler) registerVersion(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, uri string, credentials *internal.Credentials, flyteAuth flyte.Auth, targets []target) []targetOutcome {
+	workflowVersion := targets[0].version
+	outcomes := make([]targetOutcome, 0, len(targets))
+	fail := func(err *targetError) []targetOutcome {
+		for _, t := range targets {
+			outcomes = append(outcomes, targetOutcome{target: t, err: err})
+		}
+		return outcomes
+	}
+
+	r.Recorder.Eventf(flyteWorkflow, corev1.EventTypeNormal, EventReasonDownloadStarted,
+		"Downloading %s version %s", uri, workflowVersion)
+
+	ws, err := r.Workspaces.Create(fmt.Sprintf("%s-%s", flyteWorkflow.Namespace, flyteWorkflow.Name))
+	if err != nil {
+		return fail(&targetError{v1.ConditionTypeDownloaded, v1.ReasonDownloadFailed, err})
+	}
+	defer func() {
+		if err := ws.Remove(); err != nil {
+			log.FromContext(ctx).Error(err, "failed to remove workspace", "dir", ws.Dir)
+		}
+	}()
+
+	// The package is verified once for all the targets, when any of their domains requires signed packages
+	var verifySignature bool
+	for _, t := range targets {
+		verifySignature = verifySignature || r.Config.SignatureRequired(t.domain)
+	}
+
+	strategy := r.strategy(uri)
+	downloadStart := time.Now()
+	artifact, err := r.Downloader.DownloadArtifact(ctx, internal.DownloadRequest{
+		URI:             uri,
+		Version:         workflowVersion,
+		Credentials:     credentials,
+		Workspace:       ws,
+		Digest:          flyteWorkflow.Spec.PackageDigest,
+		VerifySignature: verifySignature,
+	})
+	if err != nil {
+		conditionType, reason := v1.ConditionTypeDownloaded, v1.ReasonDownloadFailed
+		switch {
+		case errors.Is(err, digest.ErrMismatch):
+			reason = v1.ReasonDigestMismatch
+		case errors.Is(err, signature.ErrUnverified):
+			conditionType, reason = v1.ConditionTypeVerified, v1.ReasonSignatureVerificationFailed
+		}
+		return fail(&targetError{conditionType, reason, fmt.Errorf("failed to download artifact: %w", err)})
+	}
+
+	log.Log.Info("downloaded artifact", "path", artifact.Path, "digest", artifact.Digest)
+	if info, err := os.Stat(artifact.Path); err == nil {
+		metrics.ObserveDownload(strategy, time.Since(downloadStart), info.Size())
+	}
+
+	// A version should always resolve to the same package, a different digest means it was overwritten at the source
+	for _, t := range targets {
+		previous := previousTargetStatus(flyteWorkflow, t)
+		if previous.ResolvedDigest != "" && previous.ResolvedDigest != artifact.Digest &&
+			previous.WorkflowVersion == workflowVersion && flyteWorkflow.Status.WorkflowPackageURI == uri {
+			r.Recorder.Eventf(flyteWorkflow, corev1.EventTypeWarning, EventReasonDigestChanged,
+				"Version %s resolved to %s, it was registered from %s", workflowVersion, artifact.Digest, previous.ResolvedDigest)
+			break
+		}
+	}
+
+	message := fmt.Sprintf("downloaded %s version %s with digest %s", uri, workflowVersion, artifact.Digest)
+	setCondition(flyteWorkflow, v1.ConditionTypeDownloaded, metav1.ConditionTrue, v1.ReasonDownloadSucceeded, message)
+	r.Recorder.Event(flyteWorkflow, corev1.EventTypeNormal, EventReasonDownloadSucceeded, message)
+
+	if verifySignature {
+		setCondition(flyteWorkflow, v1.ConditionTypeVerified, metav1.ConditionTrue, v1.ReasonSignatureVerified,
+			fmt.Sprintf("verified the signature of %s version %s", uri, workflowVersion))
 	}
 
-	flyteAuth := flyte.Auth{
-		AdminEndpoint:      r.Config.FlyteAdminEndpoint,
-		ClientID:           r.Config.FlyteClientID,
-		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	for _, t := range targets {
+		meta := flyte.WorkflowMetadata{
+			WorkflowVersion: workflowVersion,
+			Domain:          t.domain,
+			Project:         t.project,
+		}
+
+		registrationStart := time.Now()
+		results, err := r.FlyteAdminClient.RegisterWorkflow(ctx, artifact.Path, meta, flyteAuth)
+		metrics.ObserveRegistration(t.project, t.domain, strategy, time.Since(registrationStart), err)
+		if err != nil {
+			outcomes = append(outcomes, targetOutcome{target: t, err: &targetError{v1.ConditionTypeRegistered, v1.ReasonRegistrationFailed,
+				fmt.Errorf("failed to register workflow %w", err)}})
+			continue
+		}
+
+		message := fmt.Sprintf("registered %d entities with version %s in %s/%s", len(results), workflowVersion, t.project, t.domain)
+		r.Recorder.Event(flyteWorkflow, corev1.EventTypeNormal, EventReasonRegistrationSucceeded, message)
+
+		// flytectl does not activate the launch plans it registers
+		launchPlans, err := r.updateLaunchPlans(ctx, flyteWorkflow, t, flyteAuth)
+		if err != nil {
+			outcomes = append(outcomes, targetOutcome{target: t, launchPlans: launchPlans, err: &targetError{v1.ConditionTypeRegistered,
+				v1.ReasonLaunchPlanUpdateFailed, fmt.Errorf("failed to update launch plans: %w", err)}})
+			continue
+		}
+
+		outcomes = append(outcomes, targetOutcome{target: t, artifact: artifact, results: results, launchPlans: launchPlans, message: message})
 	}
 
-	fullArtifactPath, err := r.Downloader.DownloadArtifact(ctx, workflowPackageURI, workflowVersion)
+	return outcomes
+}
+
+// resolveVersion lists the versions of the workflow package and returns the highest version that matches the version
+// policy, recording it on the status. An Event is emitted when the policy resolves to another version than before.
+func (r *FlyteRegistrationReconciler) resolveVersion(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, uri string, credentials *internal.Credentials) (string, error) {
+	versions, err := r.Downloader.ListVersions(ctx, internal.VersionsRequest{URI: uri, Credentials: credentials})
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to download artifact: %w", err)
+		return "", fmt.Errorf("failed to list versions of %s: %w", uri, err)
 	}
 
-	log.Log.Info("downloaded artifact", "path", fullArtifactPath)
+	resolved, err := version.Resolve(flyteWorkflow.Spec.VersionPolicy, versions)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve version of %s: %w", uri, err)
+	}
 
-	if err := r.FlyteAdminClient.RegisterWorkflow(ctx, fullArtifactPath, meta, flyteAuth); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to register workflow %w", err)
+	if resolved != flyteWorkflow.Status.ResolvedVersion {
+		log.FromContext(ctx).Info("resolved version policy", "name", flyteWorkflow.Name, "policy", flyteWorkflow.Spec.VersionPolicy, "version", resolved)
+		r.Recorder.Eventf(flyteWorkflow, corev1.EventTypeNormal, EventReasonVersionResolved,
+			"Version policy %s resolved to version %s", flyteWorkflow.Spec.VersionPolicy, resolved)
+	}
+	flyteWorkflow.Status.ResolvedVersion = resolved
+
+	return resolved, nil
+}
+
+// result returns the result of a successful reconciliation, the FlyteRegistrations with a version policy are
+// requeued to poll for new versions
+func (r *FlyteRegistrationReconciler) result(flyteWorkflow *v1.FlyteRegistration) ctrl.Result {
+	if flyteWorkflow.Spec.VersionPolicy == "" {
+		return ctrl.Result{}
+	}
+
+	if flyteWorkflow.Spec.VersionPollInterval != nil && flyteWorkflow.Spec.VersionPollInterval.Duration > 0 {
+		return ctrl.Result{RequeueAfter: flyteWorkflow.Spec.VersionPollInterval.Duration}
+	}
+
+	return ctrl.Result{RequeueAfter: r.Config.VersionPollInterval}
+}
+
+// waitingForProject returns why the FlyteRegistration waits for the FlyteProject of one of the projects it registers the
+// workflow package into, or an empty string when the FlyteProjects of all of them are Ready
+func (r *FlyteRegistrationReconciler) waitingForProject(ctx context.Context, targets []target) (string, error) {
+	checked := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		if checked[t.project] {
+			continue
+		}
+		checked[t.project] = true
+
+		var project v1.FlyteProject
+		err := r.K8sClient.Get(ctx, client.ObjectKey{Name: t.project}, &project)
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("FlyteProject %s does not exist", t.project), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get FlyteProject %s: %w", t.project, err)
+		}
+
+		if project.Status.ObservedGeneration != project.Generation ||
+			!apimeta.IsStatusConditionTrue(project.Status.Conditions, v1.ConditionTypeReady) {
+			return fmt.Sprintf("FlyteProject %s is not Ready", t.project), nil
+		}
+	}
+
+	return "", nil
+}
+
+// reconcileWaiting reports that the FlyteRegistration waits for a FlyteProject. The status is only written, and the
+// Event emitted, when the FlyteRegistration starts waiting for another reason.
+func (r *FlyteRegistrationReconciler) reconcileWaiting(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, waiting string) (ctrl.Result, error) {
+	if !setCondition(flyteWorkflow, v1.ConditionTypeReady, metav1.ConditionFalse, v1.ReasonProjectNotReady, waiting) {
+		return ctrl.Result{}, nil
+	}
+
+	log.Log.Info("waiting for project", "name", flyteWorkflow.Name, "reason", waiting)
+	r.Recorder.Eventf(flyteWorkflow, corev1.EventTypeNormal, EventReasonProjectNotReady, "Waiting to register: %s", waiting)
+
+	if err := r.K8sClient.Status().Update(ctx, flyteWorkflow); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
 	}
 
-	log.Log.Info("successfully registered workflow", "name", req.Name, "version", workflowVersion, "domain", workflowDomain, "project", workflowProject)
 	return ctrl.Result{}, nil
 }
+
+// registrationsForProject returns the requests for the FlyteRegistrations that wait for a FlyteProject, the ones in any
+// namespace that register into its project
+func (r *FlyteRegistrationReconciler) registrationsForProject(ctx context.Context, project client.Object) []reconcile.Request {
+	var registrations v1.FlyteRegistrationList
+	if err := r.K8sClient.List(ctx, &registrations); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list FlyteRegistrations", "project", project.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range registrations.Items {
+		flyteWorkflow := &registrations.Items[i]
+		if !flyteWorkflow.Spec.WaitForProject {
+			continue
+		}
+
+		for _, t := range registrationTargets(flyteWorkflow, "") {
+			if t.project == project.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(flyteWorkflow)})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// reconcileSuspended reports that a FlyteRegistration is suspended, without downloading or registering anything. The
+// status is only written when the Suspended condition is not reported for the current generation yet.
+func (r *FlyteRegistrationReconciler) reconcileSuspended(ctx context.Context, flyteWorkflow *v1.FlyteRegistration) (ctrl.Result, error) {
+	condition := apimeta.FindStatusCondition(flyteWorkflow.Status.Conditions, v1.ConditionTypeSuspended)
+	if condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == flyteWorkflow.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	log.Log.Info("reconciliation suspended", "name", flyteWorkflow.Name)
+	setCondition(flyteWorkflow, v1.ConditionTypeSuspended, metav1.ConditionTrue, v1.ReasonSuspended, "reconciliation is suspended")
+	r.Recorder.Event(flyteWorkflow, corev1.EventTypeNormal, EventReasonSuspended, "Reconciliation is suspended")
+
+	if err := r.K8sClient.Status().Update(ctx, flyteWorkflow); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// resume removes the Suspended condition of a FlyteRegistration that is no longer suspended
+func (r *FlyteRegistrationReconciler) resume(ctx context.Context, flyteWorkflow *v1.FlyteRegistration) error {
+	if !apimeta.RemoveStatusCondition(&flyteWorkflow.Status.Conditions, v1.ConditionTypeSuspended) {
+		return nil
+	}
+
+	log.Log.Info("reconciliation resumed", "name", flyteWorkflow.Name)
+	r.Recorder.Event(flyteWorkflow, corev1.EventTypeNormal, EventReasonResumed, "Reconciliation is resumed")
+
+	if err := r.K8sClient.Status().Update(ctx, flyteWorkflow); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// ensureFinalizer adds the finalizer to a FlyteRegistration with the Archive deletion policy, and removes it when the
+// deletion policy is changed back to Retain
+func (r *FlyteRegistrationReconciler) ensureFinalizer(ctx context.Context, flyteWorkflow *v1.FlyteRegistration) error {
+	var updated bool
+	if flyteWorkflow.Spec.DeletionPolicy == v1.DeletionPolicyArchive {
+		updated = controllerutil.AddFinalizer(flyteWorkflow, v1.Finalizer)
+	} else {
+		updated = controllerutil.RemoveFinalizer(flyteWorkflow, v1.Finalizer)
+	}
+
+	if !updated {
+		return nil
+	}
+
+	if err := r.K8sClient.Update(ctx, flyteWorkflow); err != nil {
+		return fmt.Errorf("failed to update finalizers: %w", err)
+	}
+
+	return nil
+}
+
+// clearForceRegistration removes the force-registration annotation once the registration it forced has succeeded, so
+// that the workflow package is registered again only once for each time the annotation is set
+func (r *FlyteRegistrationReconciler) clearForceRegistration(ctx context.Context, flyteWorkflow *v1.FlyteRegistration) error {
+	if flyteWorkflow.Annotations[v1.ForceRegistrationAnnotation] != "true" {
+		return nil
+	}
+
+	delete(flyteWorkflow.Annotations, v1.ForceRegistrationAnnotation)
+	if err := r.K8sClient.Update(ctx, flyteWorkflow); err != nil {
+		return fmt.Errorf("failed to remove the %s annotation: %w", v1.ForceRegistrationAnnotation, err)
+	}
+
+	return nil
+}
+
+// reconcileDelete archives the entities registered from the last successfully registered version of the workflow
+// package in each target, as recorded on the status, apart from the workflows other FlyteRegistrations still use, and
+// then removes the finalizer
+// so that the FlyteRegistration can be deleted
+func (r *FlyteRegistrationReconciler) reconcileDelete(ctx context.Context, flyteWorkflow *v1.FlyteRegistration) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(flyteWorkflow, v1.Finalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	// Nothing was registered if the status does not hold a version
+	registered := registeredTargets(flyteWorkflow)
+	if len(registered) > 0 {
+		flyteAuth, err := r.flyteAuth(ctx, flyteWorkflow)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// Only the entities this FlyteRegistration registered are archived, other workflow packages may register
+		// entities with the same version into the same project and domain
+		var launchPlans, workflows []string
+		if entities := flyteWorkflow.Status.RegisteredEntities; entities != nil {
+			launchPlans = entities.LaunchPlans
+			workflows = entities.Workflows
+		}
+
+		for _, meta := range registered {
+			retain, err := r.sharedWorkflows(ctx, flyteWorkflow, meta)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			var archive []string
+			for _, name := range workflows {
+				if !slices.Contains(retain, name) {
+					archive = append(archive, name)
+				}
+			}
+
+			if err := r.FlyteAdminClient.ArchiveWorkflow(ctx, meta, launchPlans, archive, flyteAuth); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to archive workflow: %w", err)
+			}
+
+			log.Log.Info("archived workflow", "name", flyteWorkflow.Name, "version", meta.WorkflowVersion, "domain", meta.Domain, "project", meta.Project)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(flyteWorkflow, v1.Finalizer)
+	if err := r.K8sClient.Update(ctx, flyteWorkflow); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// sharedWorkflows returns the names of the workflows that the other FlyteRegistrations have registered into the
+// project and domain of a target. Flyte archives a workflow with all of its versions, so these are not archived when
+// the FlyteRegistration is deleted.
+func (r *FlyteRegistrationReconciler) sharedWorkflows(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, meta flyte.WorkflowMetadata) ([]string, error) {
+	var registrations v1.FlyteRegistrationList
+	if err := r.K8sClient.List(ctx, &registrations); err != nil {
+		return nil, fmt.Errorf("failed to list FlyteRegistrations: %w", err)
+	}
+
+	var shared []string
+	for i := range registrations.Items {
+		other := &registrations.Items[i]
+		if other.UID == flyteWorkflow.UID || !other.DeletionTimestamp.IsZero() || other.Status.RegisteredEntities == nil {
+			continue
+		}
+
+		// The same workflow package registers the same workflows into each of its targets
+		for _, registered := range registeredTargets(other) {
+			if registered.Project == meta.Project && registered.Domain == meta.Domain {
+				shared = append(shared, other.Status.RegisteredEntities.Workflows...)
+				break
+			}
+		}
+	}
+
+	return shared, nil
+}
+
+// failReconcile records a failed reconciliation on the status of the FlyteRegistration and in a Warning Event, with the
+// reason of the condition, and returns the original error so that the request is retried with back-off
+func (r *FlyteRegistrationReconciler) failReconcile(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, conditionType string, reason string, err error) (ctrl.Result, error) {
+	if statusErr := r.recordFailure(ctx, flyteWorkflow, conditionType, reason, err); statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "failed to update status", "name", flyteWorkflow.Name)
+	}
+
+	return ctrl.Result{}, err
+}
+
+// recordFailure records a failure on the status of the FlyteRegistration and in a Warning Event, with the reason of the
+// condition, and returns the error of the status update
+func (r *FlyteRegistrationReconciler) recordFailure(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, conditionType string, reason string, err error) error {
+	setCondition(flyteWorkflow, conditionType, metav1.ConditionFalse, reason, err.Error())
+	setCondition(flyteWorkflow, v1.ConditionTypeReady, metav1.ConditionFalse, reason, err.Error())
+	flyteWorkflow.Status.LastError = err.Error()
+	r.Recorder.Event(flyteWorkflow, corev1.EventTypeWarning, reason, truncateMessage(err.Error()))
+	metrics.SetFailed(client.ObjectKeyFromObject(flyteWorkflow), true)
+
+	return r.K8sClient.Status().Update(ctx, flyteWorkflow)
+}
+
+// strategy returns the download strategy of a package URI, for labelling metrics
+func (r *FlyteRegistrationReconciler) strategy(uri string) string {
+	return downloader.Strategy(uri, "", r.Config.DownloadStrategy)
+}
+
+// registeredEntities lists the names of the successfully registered entities by resource type, capping each list at
+// maxRegisteredEntities names
+func registeredEntities(results []flyte.RegistrationResult) *v1.RegisteredEntities {
+	entities := &v1.RegisteredEntities{}
+
+	for _, result := range results {
+		if !result.Succeeded {
+			continue
+		}
+		entities.Count++
+
+		var names *[]string
+		switch result.Entity.ResourceType {
+		case flyte.ResourceTypeTask:
+			names = &entities.Tasks
+		case flyte.ResourceTypeWorkflow:
+			names = &entities.Workflows
+		case flyte.ResourceTypeLaunchPlan:
+			names = &entities.LaunchPlans
+		default:
+			continue
+		}
+
+		if len(*names) >= maxRegisteredEntities {
+			entities.Truncated = true
+			continue
+		}
+		*names = append(*names, result.Entity.Name)
+	}
+
+	return entities
+}
+
+// isRegistered returns true when the current generation of the FlyteRegistration, with the given spec hash and workflow
+// version, has already been registered successfully. The version only changes with the spec when there is no version
+// policy. A version with a smoke test counts as registered whatever the outcome of the smoke test.
+func isRegistered(flyteWorkflow *v1.FlyteRegistration, hash string, workflowVersion string) bool {
+	return flyteWorkflow.Status.ObservedGeneration == flyteWorkflow.Generation &&
+		flyteWorkflow.Status.LastRegisteredSpecHash == hash &&
+		flyteWorkflow.Status.WorkflowVersion == workflowVersion &&
+		(apimeta.IsStatusConditionTrue(flyteWorkflow.Status.Conditions, v1.ConditionTypeReady) ||
+			smokeTested(flyteWorkflow, workflowVersion))
+}
+
+// reconcileRequested returns true when the reconcile-requested-at annotation of the FlyteRegistration holds a request
+// that has not been handled yet
+func reconcileRequested(flyteWorkflow *v1.FlyteRegistration) bool {
+	requestedAt := flyteWorkflow.Annotations[v1.ReconcileRequestedAtAnnotation]
+	return requestedAt != "" && requestedAt != flyteWorkflow.Status.LastHandledReconcileAt
+}
+
+// specHash returns a hash of the spec of a FlyteRegistration. The source type is left out when it is the download
+// strategy the package URI is downloaded with anyway, so that the source type the defaulting webhook fills in does
+// not register the FlyteRegistrations created before the webhook again.
+func specHash(spec v1.FlyteRegistrationSpec, defaultStrategy string) (string, error) {
+	if spec.Source != nil && spec.Source.Type == downloader.Strategy(spec.WorkflowPackageURI, "", defaultStrategy) {
+		source := *spec.Source
+		source.Type = ""
+		spec.Source = &source
+		if source == (v1.SourceSpec{}) {
+			spec.Source = nil
+		}
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash spec: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// setCondition sets a condition on the status of the FlyteRegistration for the current generation, and returns true
+// when the condition changed
+func setCondition(flyteWorkflow *v1.FlyteRegistration, conditionType string, status metav1.ConditionStatus, reason string, message string) bool {
+	return apimeta.SetStatusCondition(&flyteWorkflow.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: flyteWorkflow.Generation,
+	})
+}
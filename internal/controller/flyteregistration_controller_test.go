@@ -19,20 +19,41 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/controller/mocks"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/digest"
 	dMocks "github.com/adarga-ai/flyte-workflow-registration-operator/internal/downloader/mocks"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
 	fMocks "github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte/mocks"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/signature"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/version"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/workspace"
 	"github.com/stretchr/testify/assert"
 	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// Used to generate mocks for the status writer returned by the K8sClient
+//go:generate mockery --srcpkg=sigs.k8s.io/controller-runtime/pkg/client --name=SubResourceWriter
+
 func TestReconcile(t *testing.T) {
 	// SHARED INPUTS
 	testWorkflow := v1.FlyteRegistration{}
@@ -47,6 +68,11 @@ func TestReconcile(t *testing.T) {
 	workflowPackageURI := "test-uri"
 
 	artifactPath := "test-artifact-path"
+	artifactDigest := "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	artifact := internal.Artifact{Path: artifactPath, Digest: artifactDigest, ETag: "test-etag", VersionID: "test-version-id"}
+
+	// Without a credentials Secret reference the configured credentials are used
+	var noCredentials *internal.Credentials
 
 	meta := flyte.WorkflowMetadata{
 		WorkflowVersion: workflowVersion,
@@ -63,10 +89,42 @@ func TestReconcile(t *testing.T) {
 		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
 	}
 
+	// Every reconciliation downloads to a workspace of its own under the root, which is removed afterwards
+	workspaceRoot := t.TempDir()
+	workspaces := workspace.NewManager(workspaceRoot, 0)
+
+	// downloadRequest matches a request to download a package URI with the given credentials into a workspace
+	downloadRequest := func(uri string, credentials *internal.Credentials) interface{} {
+		return mock.MatchedBy(func(req internal.DownloadRequest) bool {
+			return req.URI == uri && req.Version == workflowVersion && req.Workspace != nil &&
+				assert.ObjectsAreEqual(credentials, req.Credentials)
+		})
+	}
+
 	// SHARED MOCKS
 	mockK8sClient := &mocks.K8sClient{}
 	mockDownloader := &dMocks.Client{}
 	mockFlyteAdminClient := &fMocks.Client{}
+	mockStatusWriter := &mocks.SubResourceWriter{}
+
+	// registeredStatus is the status of a FlyteRegistration whose spec has already been registered
+	registeredSpec := v1.FlyteRegistrationSpec{
+		WorkflowVersion:    workflowVersion,
+		WorkflowDomain:     workflowDomain,
+		WorkflowProject:    workflowProject,
+		WorkflowPackageURI: workflowPackageURI,
+	}
+	registeredHash, err := specHash(registeredSpec, "")
+	require.NoError(t, err)
+
+	registeredStatus := v1.FlyteRegistrationStatus{
+		ObservedGeneration:     2,
+		LastRegisteredSpecHash: registeredHash,
+		WorkflowVersion:        workflowVersion,
+		Conditions: []metav1.Condition{
+			{Type: v1.ConditionTypeReady, Status: metav1.ConditionTrue, Reason: v1.ReasonRegistrationSucceeded},
+		},
+	}
 
 	t.Run("success case", func(t *testing.T) {
 		// MOCK BEHAVIOUR
@@ -79,13 +137,28 @@ func TestReconcile(t *testing.T) {
 				arg.Spec.WorkflowPackageURI = workflowPackageURI
 			}).Return(nil).Once()
 
-		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, workflowPackageURI, workflowVersion).Return(artifactPath, nil).Once()
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		results := []flyte.RegistrationResult{
+			{Entity: flyte.Identifier{ResourceType: flyte.ResourceTypeTask, Name: "test-task"}, Succeeded: true},
+			{Entity: flyte.Identifier{ResourceType: flyte.ResourceTypeWorkflow, Name: "test-workflow"}, Succeeded: true},
+			{Entity: flyte.Identifier{ResourceType: flyte.ResourceTypeLaunchPlan, Name: "test-workflow"}, Succeeded: true},
+		}
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(results, nil).Once()
 
-		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil).Once()
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
 
 		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
 		reconciler := &FlyteRegistrationReconciler{
 			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
 			Downloader:       mockDownloader,
 			FlyteAdminClient: mockFlyteAdminClient,
 			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
@@ -95,76 +168,156 @@ func TestReconcile(t *testing.T) {
 
 		// ASSERTIONS
 		assert.NoError(t, err)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeDownloaded))
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeRegistered))
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+		assert.Equal(t, workflowVersion, status.WorkflowVersion)
+		assert.NotNil(t, status.LastSuccessTime)
+		assert.Empty(t, status.LastError)
+		assert.NotEmpty(t, status.LastRegisteredSpecHash)
+		assert.Equal(t, artifactDigest, status.ResolvedDigest)
+		assert.Equal(t, "test-etag", status.ResolvedETag)
+		assert.Equal(t, "test-version-id", status.ResolvedVersionID)
+		assert.Equal(t, &v1.RegisteredEntities{
+			Count:       3,
+			Tasks:       []string{"test-task"},
+			Workflows:   []string{"test-workflow"},
+			LaunchPlans: []string{"test-workflow"},
+		}, status.RegisteredEntities)
+		assert.Equal(t, "Normal DownloadStarted Downloading test-uri version 1.0.0", <-recorder.Events)
+		assert.Equal(t, "Normal DownloadSucceeded downloaded test-uri version 1.0.0 with digest "+artifactDigest, <-recorder.Events)
+		workspaceEntries, err := os.ReadDir(workspaceRoot)
+		assert.NoError(t, err)
+		assert.Empty(t, workspaceEntries)
+		assert.Equal(t, "Normal RegistrationSucceeded registered 3 entities with version 1.0.0 in test-project/test-domain", <-recorder.Events)
 	})
 
-	t.Run("failure case: k8s client get method", func(t *testing.T) {
+	t.Run("success case: credentials from secret references", func(t *testing.T) {
 		// MOCK BEHAVIOUR
 		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
 			Run(func(args mock.Arguments) {
 				arg := args.Get(2).(*v1.FlyteRegistration)
-				arg.Spec.WorkflowVersion = workflowVersion
-				arg.Spec.WorkflowDomain = workflowDomain
-				arg.Spec.WorkflowProject = workflowProject
-				arg.Spec.WorkflowPackageURI = workflowPackageURI
-			}).Return(errors.New("test error")).Once()
+				arg.Namespace = req.Namespace
+				arg.Spec = registeredSpec
+				arg.Spec.Source = &v1.SourceSpec{CredentialsSecretRef: &corev1.LocalObjectReference{Name: "source-credentials"}}
+				arg.Spec.Flyte = &v1.FlyteSpec{CredentialsSecretRef: &corev1.LocalObjectReference{Name: "flyte-credentials"}}
+			}).Return(nil).Once()
+
+		// Secrets are read from the API server rather than the cache
+		mockAPIReader := mocks.NewK8sClient(t)
+		mockAPIReader.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: req.Namespace, Name: "source-credentials"}, mock.AnythingOfType("*v1.Secret")).
+			Run(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) {
+				obj.(*corev1.Secret).Data = map[string][]byte{"username": []byte("test-user"), "password": []byte("test-password")}
+			}).Return(nil).Once()
+
+		mockAPIReader.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: req.Namespace, Name: "flyte-credentials"}, mock.AnythingOfType("*v1.Secret")).
+			Run(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) {
+				obj.(*corev1.Secret).Data = map[string][]byte{"clientId": []byte("tenant-client-id"), "clientSecret": []byte("tenant-client-secret")}
+			}).Return(nil).Once()
+
+		credentials := &internal.Credentials{Username: "test-user", Password: "test-password"}
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, credentials)).Return(artifact, nil).Once()
+
+		tenantAuth := flyte.Auth{
+			AdminEndpoint:      flyteAdminEndpoint,
+			ClientID:           "tenant-client-id",
+			ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+			ClientSecret:       "tenant-client-secret",
+		}
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, tenantAuth).Return(nil, nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
 
 		// EXECUTION
 		reconciler := &FlyteRegistrationReconciler{
 			K8sClient:        mockK8sClient,
+			APIReader:        mockAPIReader,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
 			Downloader:       mockDownloader,
 			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
 		}
 
 		_, err := reconciler.Reconcile(context.Background(), req)
 
 		// ASSERTIONS
-		assert.ErrorContains(t, err, "test error")
+		assert.NoError(t, err)
 	})
 
-	t.Run("failure case: downloader downloadArtifact method", func(t *testing.T) {
+	t.Run("success case: bearer token from the source secret reference", func(t *testing.T) {
 		// MOCK BEHAVIOUR
 		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
 			Run(func(args mock.Arguments) {
 				arg := args.Get(2).(*v1.FlyteRegistration)
-				arg.Spec.WorkflowVersion = workflowVersion
-				arg.Spec.WorkflowDomain = workflowDomain
-				arg.Spec.WorkflowProject = workflowProject
-				arg.Spec.WorkflowPackageURI = workflowPackageURI
+				arg.Namespace = req.Namespace
+				arg.Spec = registeredSpec
+				arg.Spec.Source = &v1.SourceSpec{CredentialsSecretRef: &corev1.LocalObjectReference{Name: "source-token"}}
 			}).Return(nil).Once()
 
-		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, workflowPackageURI, workflowVersion).Return("", errors.New("test error")).Once()
+		// Secrets are read from the API server rather than the cache
+		mockAPIReader := mocks.NewK8sClient(t)
+		mockAPIReader.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: req.Namespace, Name: "source-token"}, mock.AnythingOfType("*v1.Secret")).
+			Run(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) {
+				obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("test-token")}
+			}).Return(nil).Once()
+
+		credentials := &internal.Credentials{Token: "test-token"}
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, credentials)).Return(artifact, nil).Once()
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
 
 		// EXECUTION
 		reconciler := &FlyteRegistrationReconciler{
 			K8sClient:        mockK8sClient,
+			APIReader:        mockAPIReader,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
 			Downloader:       mockDownloader,
 			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
 		}
 
 		_, err := reconciler.Reconcile(context.Background(), req)
 
 		// ASSERTIONS
-		assert.ErrorContains(t, err, "failed to download artifact")
+		assert.NoError(t, err)
 	})
 
-	t.Run("failure case: flyte client registerWorkflow", func(t *testing.T) {
+	t.Run("failure case: bearer token for an s3 package", func(t *testing.T) {
 		// MOCK BEHAVIOUR
 		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
 			Run(func(args mock.Arguments) {
 				arg := args.Get(2).(*v1.FlyteRegistration)
-				arg.Spec.WorkflowVersion = workflowVersion
-				arg.Spec.WorkflowDomain = workflowDomain
-				arg.Spec.WorkflowProject = workflowProject
-				arg.Spec.WorkflowPackageURI = workflowPackageURI
+				arg.Namespace = req.Namespace
+				arg.Spec = registeredSpec
+				arg.Spec.WorkflowPackageURI = "s3://packages/data-warehouse"
+				arg.Spec.Source = &v1.SourceSpec{CredentialsSecretRef: &corev1.LocalObjectReference{Name: "source-token"}}
 			}).Return(nil).Once()
 
-		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, workflowPackageURI, workflowVersion).Return(artifactPath, nil).Once()
+		// Secrets are read from the API server rather than the cache
+		mockAPIReader := mocks.NewK8sClient(t)
+		mockAPIReader.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: req.Namespace, Name: "source-token"}, mock.AnythingOfType("*v1.Secret")).
+			Run(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) {
+				obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("test-token")}
+			}).Return(nil).Once()
 
-		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(errors.New("test error")).Once()
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
 
 		// EXECUTION
 		reconciler := &FlyteRegistrationReconciler{
 			K8sClient:        mockK8sClient,
+			APIReader:        mockAPIReader,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
 			Downloader:       mockDownloader,
 			FlyteAdminClient: mockFlyteAdminClient,
 			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
@@ -173,6 +326,2169 @@ func TestReconcile(t *testing.T) {
 		_, err := reconciler.Reconcile(context.Background(), req)
 
 		// ASSERTIONS
-		assert.ErrorContains(t, err, "failed to register workflow")
+		assert.ErrorIs(t, err, internal.ErrTokenUnsupported)
+		condition := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeDownloaded)
+		require.NotNil(t, condition)
+		assert.Equal(t, v1.ReasonCredentialsUnavailable, condition.Reason)
+	})
+
+	t.Run("success case: credentials from the configured secret", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Namespace = req.Namespace
+				arg.Spec = registeredSpec
+			}).Return(nil).Once()
+
+		// Secrets are read from the API server rather than the cache
+		mockAPIReader := mocks.NewK8sClient(t)
+		mockAPIReader.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: "operator", Name: "flyte-credentials"}, mock.AnythingOfType("*v1.Secret")).
+			Run(func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) {
+				obj.(*corev1.Secret).Data = map[string][]byte{"clientId": []byte("rotated-client-id"), "clientSecret": []byte("rotated-client-secret")}
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		rotatedAuth := flyte.Auth{
+			AdminEndpoint:      flyteAdminEndpoint,
+			ClientID:           "rotated-client-id",
+			ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+			ClientSecret:       "rotated-client-secret",
+		}
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, rotatedAuth).Return(nil, nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			APIReader:        mockAPIReader,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config: internal.Config{
+				FlyteAdminEndpoint:              flyteAdminEndpoint,
+				FlyteCredentialsSecretName:      "flyte-credentials",
+				FlyteCredentialsSecretNamespace: "operator",
+			},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: missing credentials secret", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Namespace = req.Namespace
+				arg.Spec = registeredSpec
+				arg.Spec.Source = &v1.SourceSpec{CredentialsSecretRef: &corev1.LocalObjectReference{Name: "missing-credentials"}}
+			}).Return(nil).Once()
+
+		// Secrets are read from the API server rather than the cache
+		mockAPIReader := mocks.NewK8sClient(t)
+		mockAPIReader.EXPECT().Get(mock.Anything, types.NamespacedName{Namespace: req.Namespace, Name: "missing-credentials"}, mock.AnythingOfType("*v1.Secret")).
+			Return(errors.New("not found")).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			APIReader:        mockAPIReader,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to read source credentials")
+		condition := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeDownloaded)
+		require.NotNil(t, condition)
+		assert.Equal(t, v1.ReasonCredentialsUnavailable, condition.Reason)
+	})
+
+	t.Run("success case: unchanged spec is skipped", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Spec = registeredSpec
+				arg.Status = registeredStatus
+			}).Return(nil).Once()
+
+		// The downloader and flyte client must not be called
+		unusedDownloader := dMocks.NewClient(t)
+		unusedFlyteAdminClient := fMocks.NewClient(t)
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       unusedDownloader,
+			FlyteAdminClient: unusedFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, "Normal SkippedUnchanged Version 1.0.0 has already been registered, skipping", <-recorder.Events)
+	})
+
+	t.Run("success case: unchanged spec is registered when forced", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Annotations = map[string]string{v1.ForceRegistrationAnnotation: "true"}
+				arg.Spec = registeredSpec
+				arg.Status = registeredStatus
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		// The annotation is removed once the forced registration has succeeded
+		var annotations map[string]string
+		mockK8sClient.EXPECT().Update(mock.Anything, mock.AnythingOfType("*v1.FlyteRegistration")).
+			Run(func(_ context.Context, obj client.Object, _ ...client.UpdateOption) {
+				annotations = obj.GetAnnotations()
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.NotNil(t, annotations)
+		assert.NotContains(t, annotations, v1.ForceRegistrationAnnotation)
+	})
+
+	t.Run("failure case: failed forced registration keeps the annotation", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		// The K8s client does not expect an update, the annotation is only removed after a successful registration
+		k8sClient := mocks.NewK8sClient(t)
+		k8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Annotations = map[string]string{v1.ForceRegistrationAnnotation: "true"}
+				arg.Spec = registeredSpec
+				arg.Status = registeredStatus
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, errors.New("test error")).Once()
+
+		k8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        k8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "test error")
+	})
+
+	t.Run("success case: unchanged spec is registered when a reconcile is requested", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Annotations = map[string]string{v1.ReconcileRequestedAtAnnotation: "2025-01-02T00:00:00Z"}
+				arg.Spec = registeredSpec
+				arg.Status = registeredStatus
+				arg.Status.LastHandledReconcileAt = "2025-01-01T00:00:00Z"
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-01-02T00:00:00Z", status.LastHandledReconcileAt)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+	})
+
+	t.Run("success case: handled reconcile request is skipped", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Annotations = map[string]string{v1.ReconcileRequestedAtAnnotation: "2025-01-02T00:00:00Z"}
+				arg.Spec = registeredSpec
+				arg.Status = registeredStatus
+				arg.Status.LastHandledReconcileAt = "2025-01-02T00:00:00Z"
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: fMocks.NewClient(t),
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, "Normal SkippedUnchanged Version 1.0.0 has already been registered, skipping", <-recorder.Events)
+	})
+
+	t.Run("success case: suspended registration reports the suspended condition", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 3
+				arg.Spec = registeredSpec
+				arg.Spec.WorkflowVersion = "2.0.0"
+				arg.Spec.Suspend = true
+				arg.Status = registeredStatus
+			}).Return(nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: fMocks.NewClient(t),
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		condition := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeSuspended)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, int64(3), condition.ObservedGeneration)
+		assert.Equal(t, workflowVersion, status.WorkflowVersion)
+		assert.Equal(t, "Normal Suspended Reconciliation is suspended", <-recorder.Events)
+	})
+
+	t.Run("success case: suspended registration that reports the suspended condition is left alone", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 3
+				arg.Spec = registeredSpec
+				arg.Spec.Suspend = true
+				arg.Status = registeredStatus
+				arg.Status.Conditions = []metav1.Condition{
+					{Type: v1.ConditionTypeSuspended, Status: metav1.ConditionTrue, Reason: v1.ReasonSuspended, ObservedGeneration: 3},
+				}
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: fMocks.NewClient(t),
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("success case: resumed registration removes the suspended condition", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Spec = registeredSpec
+				arg.Status = registeredStatus
+				arg.Status.Conditions = append([]metav1.Condition{
+					{Type: v1.ConditionTypeSuspended, Status: metav1.ConditionTrue, Reason: v1.ReasonSuspended},
+				}, registeredStatus.Conditions...)
+			}).Return(nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: fMocks.NewClient(t),
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Nil(t, apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeSuspended))
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+		assert.Equal(t, "Normal Resumed Reconciliation is resumed", <-recorder.Events)
+		assert.Equal(t, "Normal SkippedUnchanged Version 1.0.0 has already been registered, skipping", <-recorder.Events)
+	})
+
+	t.Run("success case: source type selects the downloader of a bare uri", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+				arg.Spec.Source = &v1.SourceSpec{Type: internal.DownloadStrategyJFrog}
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest("jfrog://"+workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("success case: changed digest of a registered version is reported", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 3
+				arg.Spec = registeredSpec
+				arg.Status = registeredStatus
+				arg.Status.WorkflowVersion = workflowVersion
+				arg.Status.WorkflowPackageURI = workflowPackageURI
+				arg.Status.ResolvedDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, "Normal DownloadStarted Downloading test-uri version 1.0.0", <-recorder.Events)
+		assert.Contains(t, <-recorder.Events, "Warning DigestChanged Version 1.0.0 resolved to "+artifactDigest)
+	})
+
+	t.Run("failure case: package does not have the pinned digest", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		pinnedDigest := "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+				arg.Spec.PackageDigest = pinnedDigest
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, mock.MatchedBy(func(req internal.DownloadRequest) bool {
+			return req.Digest == pinnedDigest
+		})).Return(internal.Artifact{}, fmt.Errorf("%w: pinned %s, resolved %s", digest.ErrMismatch, pinnedDigest, artifactDigest)).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorIs(t, err, digest.ErrMismatch)
+		downloaded := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeDownloaded)
+		require.NotNil(t, downloaded)
+		assert.Equal(t, v1.ReasonDigestMismatch, downloaded.Reason)
+	})
+
+	t.Run("success case: signed package in a domain that requires signatures is verified", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, mock.MatchedBy(func(req internal.DownloadRequest) bool {
+			return req.VerifySignature
+		})).Return(artifact, nil).Once()
+
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config: internal.Config{
+				FlyteClientID:            flyteClientID,
+				FlyteAdminEndpoint:       flyteAdminEndpoint,
+				SignatureRequiredDomains: []string{workflowDomain},
+			},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		verified := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeVerified)
+		require.NotNil(t, verified)
+		assert.Equal(t, metav1.ConditionTrue, verified.Status)
+		assert.Equal(t, v1.ReasonSignatureVerified, verified.Reason)
+	})
+
+	t.Run("failure case: package is not signed by a trusted key", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, mock.MatchedBy(func(req internal.DownloadRequest) bool {
+			return req.VerifySignature
+		})).Return(internal.Artifact{}, fmt.Errorf("%w: test-uri is not signed", signature.ErrUnverified)).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config: internal.Config{
+				FlyteClientID:            flyteClientID,
+				FlyteAdminEndpoint:       flyteAdminEndpoint,
+				SignatureRequiredDomains: []string{"*"},
+			},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorIs(t, err, signature.ErrUnverified)
+		verified := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeVerified)
+		require.NotNil(t, verified)
+		assert.Equal(t, metav1.ConditionFalse, verified.Status)
+		assert.Equal(t, v1.ReasonSignatureVerificationFailed, verified.Reason)
+		ready := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, v1.ReasonSignatureVerificationFailed, ready.Reason)
+	})
+
+	t.Run("success case: version policy registers the highest matching version", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+				arg.Spec.WorkflowVersion = ""
+				arg.Spec.VersionPolicy = "^1.0"
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().ListVersions(mock.Anything, internal.VersionsRequest{URI: workflowPackageURI}).
+			Return([]string{"0.9.0", "1.0.0", "1.0.0-rc.1", "2.0.0", "latest"}, nil).Once()
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint, VersionPollInterval: 5 * time.Minute},
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, result.RequeueAfter)
+		assert.Equal(t, workflowVersion, status.ResolvedVersion)
+		assert.Equal(t, workflowVersion, status.WorkflowVersion)
+		assert.Equal(t, "Normal VersionResolved Version policy ^1.0 resolved to version 1.0.0", <-recorder.Events)
+	})
+
+	t.Run("success case: version policy that resolves to the registered version is skipped", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		policySpec := registeredSpec
+		policySpec.WorkflowVersion = ""
+		policySpec.VersionPolicy = "^1.0"
+		policySpec.VersionPollInterval = &metav1.Duration{Duration: time.Minute}
+		policyHash, err := specHash(policySpec, "")
+		require.NoError(t, err)
+
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Spec = policySpec
+				arg.Status = registeredStatus
+				arg.Status.LastRegisteredSpecHash = policyHash
+				arg.Status.ResolvedVersion = workflowVersion
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().ListVersions(mock.Anything, internal.VersionsRequest{URI: workflowPackageURI}).
+			Return([]string{"1.0.0", "2.0.0"}, nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: fMocks.NewClient(t),
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint, VersionPollInterval: 5 * time.Minute},
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, result.RequeueAfter)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("failure case: version policy does not match a published version", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+				arg.Spec.WorkflowVersion = ""
+				arg.Spec.VersionPolicy = ">=3"
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().ListVersions(mock.Anything, internal.VersionsRequest{URI: workflowPackageURI}).
+			Return([]string{"1.0.0", "2.0.0"}, nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint, VersionPollInterval: 5 * time.Minute},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorIs(t, err, version.ErrNoMatch)
+		condition := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeDownloaded)
+		require.NotNil(t, condition)
+		assert.Equal(t, v1.ReasonVersionResolutionFailed, condition.Reason)
+	})
+
+	t.Run("success case: package is downloaded once for each version and registered into every target", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+				arg.Spec.WorkflowDomain = ""
+				arg.Spec.Targets = []v1.RegistrationTarget{
+					{Domain: "development"},
+					{Domain: "staging"},
+					{Domain: "production", Project: "other-project", WorkflowVersion: "0.9.0"},
+				}
+			}).Return(nil).Once()
+
+		previousArtifact := internal.Artifact{Path: "test-previous-artifact-path", Digest: "sha256:previous"}
+		targetDownloader := dMocks.NewClient(t)
+		targetDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+		targetDownloader.EXPECT().DownloadArtifact(mock.Anything, mock.MatchedBy(func(req internal.DownloadRequest) bool {
+			return req.Version == "0.9.0"
+		})).Return(previousArtifact, nil).Once()
+
+		targetFlyteAdminClient := fMocks.NewClient(t)
+		targetFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath,
+			flyte.WorkflowMetadata{WorkflowVersion: workflowVersion, Domain: "development", Project: workflowProject}, flyteAuth).Return(nil, nil).Once()
+		targetFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath,
+			flyte.WorkflowMetadata{WorkflowVersion: workflowVersion, Domain: "staging", Project: workflowProject}, flyteAuth).Return(nil, nil).Once()
+		targetFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, "test-previous-artifact-path",
+			flyte.WorkflowMetadata{WorkflowVersion: "0.9.0", Domain: "production", Project: "other-project"}, flyteAuth).Return(nil, nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       targetDownloader,
+			FlyteAdminClient: targetFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+		require.Len(t, status.Targets, 3)
+		assert.Equal(t, "development", status.Targets[0].Domain)
+		assert.Equal(t, "staging", status.Targets[1].Domain)
+		assert.Equal(t, "other-project", status.Targets[2].Project)
+		assert.Equal(t, "0.9.0", status.Targets[2].WorkflowVersion)
+		assert.Equal(t, "sha256:previous", status.Targets[2].ResolvedDigest)
+		for _, target := range status.Targets {
+			assert.True(t, target.Ready)
+			assert.NotNil(t, target.LastSuccessTime)
+		}
+		assert.Equal(t, workflowVersion, status.WorkflowVersion)
+		assert.Equal(t, artifactDigest, status.ResolvedDigest)
+	})
+
+	t.Run("failure case: failing target does not stop the others", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+				arg.Spec.WorkflowDomain = ""
+				arg.Spec.Targets = []v1.RegistrationTarget{{Domain: "staging"}, {Domain: "production"}}
+				arg.Status.Targets = []v1.TargetStatus{
+					{Project: workflowProject, Domain: "production", Ready: true, WorkflowVersion: "0.9.0"},
+				}
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		targetFlyteAdminClient := fMocks.NewClient(t)
+		targetFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath,
+			flyte.WorkflowMetadata{WorkflowVersion: workflowVersion, Domain: "staging", Project: workflowProject}, flyteAuth).Return(nil, errors.New("test error")).Once()
+		targetFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath,
+			flyte.WorkflowMetadata{WorkflowVersion: workflowVersion, Domain: "production", Project: workflowProject}, flyteAuth).Return(nil, nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: targetFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "test-project/staging: failed to register workflow test error")
+		condition := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeRegistered)
+		require.NotNil(t, condition)
+		assert.Equal(t, v1.ReasonRegistrationFailed, condition.Reason)
+		require.Len(t, status.Targets, 2)
+		assert.False(t, status.Targets[0].Ready)
+		assert.Contains(t, status.Targets[0].LastError, "test error")
+		assert.True(t, status.Targets[1].Ready)
+		assert.Equal(t, workflowVersion, status.Targets[1].WorkflowVersion)
+		assert.Empty(t, status.Targets[1].LastError)
+		assert.Equal(t, "Normal DownloadStarted Downloading test-uri version 1.0.0", <-recorder.Events)
+		assert.Equal(t, "Normal DownloadSucceeded downloaded test-uri version 1.0.0 with digest "+artifactDigest, <-recorder.Events)
+		assert.Equal(t, "Normal RegistrationSucceeded registered 0 entities with version 1.0.0 in test-project/production", <-recorder.Events)
+		assert.Equal(t, "Warning RegistrationFailed test-project/staging: failed to register workflow test error", <-recorder.Events)
+	})
+
+	t.Run("success case: launch plans are activated and deactivated after the registration", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+				arg.Spec.LaunchPlans = []v1.LaunchPlanSpec{
+					{Name: "daily", State: v1.LaunchPlanStateActive},
+					{Name: "backfill", State: v1.LaunchPlanStateInactive},
+				}
+				arg.Status.LaunchPlans = []v1.LaunchPlanStatus{
+					{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: "0.9.0"},
+				}
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		previous := meta
+		previous.WorkflowVersion = "0.9.0"
+		launchPlanFlyteAdminClient := fMocks.NewClient(t)
+		launchPlanFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+		launchPlanFlyteAdminClient.EXPECT().ActivateLaunchPlan(mock.Anything, "daily", meta, flyteAuth).Return(nil).Once()
+		launchPlanFlyteAdminClient.EXPECT().DeactivateLaunchPlan(mock.Anything, "daily", previous, flyteAuth).Return(nil).Once()
+		launchPlanFlyteAdminClient.EXPECT().DeactivateLaunchPlan(mock.Anything, "backfill", meta, flyteAuth).Return(nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: launchPlanFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, []v1.LaunchPlanStatus{
+			{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: workflowVersion},
+			{Project: workflowProject, Domain: workflowDomain, Name: "backfill"},
+		}, status.LaunchPlans)
+		assert.Equal(t, "Normal DownloadStarted Downloading test-uri version 1.0.0", <-recorder.Events)
+		assert.Equal(t, "Normal DownloadSucceeded downloaded test-uri version 1.0.0 with digest "+artifactDigest, <-recorder.Events)
+		assert.Equal(t, "Normal RegistrationSucceeded registered 0 entities with version 1.0.0 in test-project/test-domain", <-recorder.Events)
+		assert.Equal(t, "Normal LaunchPlanActivated Activated launch plan daily version 1.0.0 in test-project/test-domain", <-recorder.Events)
+		assert.Equal(t, "Normal LaunchPlanDeactivated Deactivated launch plan daily version 0.9.0 in test-project/test-domain", <-recorder.Events)
+		assert.Equal(t, "Normal LaunchPlanDeactivated Deactivated launch plan backfill in test-project/test-domain", <-recorder.Events)
+	})
+
+	t.Run("failure case: launch plan cannot be activated", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+				arg.Spec.LaunchPlans = []v1.LaunchPlanSpec{{Name: "daily", State: v1.LaunchPlanStateActive}}
+				arg.Status.LaunchPlans = []v1.LaunchPlanStatus{
+					{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: "0.9.0"},
+				}
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		launchPlanFlyteAdminClient := fMocks.NewClient(t)
+		launchPlanFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+		launchPlanFlyteAdminClient.EXPECT().ActivateLaunchPlan(mock.Anything, "daily", meta, flyteAuth).Return(errors.New("test error")).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: launchPlanFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to update launch plans: test error")
+		condition := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeRegistered)
+		require.NotNil(t, condition)
+		assert.Equal(t, v1.ReasonLaunchPlanUpdateFailed, condition.Reason)
+		assert.Equal(t, []v1.LaunchPlanStatus{
+			{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: "0.9.0"},
+		}, status.LaunchPlans)
+	})
+
+	// smokeTestSpec is a spec with a smoke test, and smokeTestStatus the status of the registration of that spec while
+	// its smoke test runs
+	smokeTestSpec := registeredSpec
+	smokeTestSpec.LaunchPlans = []v1.LaunchPlanSpec{{Name: "daily", State: v1.LaunchPlanStateActive}}
+	smokeTestSpec.SmokeTest = &v1.SmokeTestSpec{
+		LaunchPlan:          "smoke",
+		Inputs:              &runtime.RawExtension{Raw: []byte(`{"count": 2}`)},
+		Timeout:             &metav1.Duration{Duration: time.Hour},
+		RollbackLaunchPlans: true,
+	}
+	smokeTestHash, err := specHash(smokeTestSpec, "")
+	require.NoError(t, err)
+
+	smokeTestStatus := func(startTime time.Time) v1.FlyteRegistrationStatus {
+		return v1.FlyteRegistrationStatus{
+			ObservedGeneration:     2,
+			LastRegisteredSpecHash: smokeTestHash,
+			WorkflowVersion:        workflowVersion,
+			Conditions: []metav1.Condition{
+				{Type: v1.ConditionTypeRegistered, Status: metav1.ConditionTrue, Reason: v1.ReasonRegistrationSucceeded},
+				{Type: v1.ConditionTypeSmokeTested, Status: metav1.ConditionUnknown, Reason: v1.ReasonSmokeTestRunning},
+				{Type: v1.ConditionTypeReady, Status: metav1.ConditionFalse, Reason: v1.ReasonSmokeTestRunning},
+			},
+			LaunchPlans: []v1.LaunchPlanStatus{
+				{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: workflowVersion},
+			},
+			SmokeTest: &v1.SmokeTestStatus{
+				Project:         workflowProject,
+				Domain:          workflowDomain,
+				ExecutionID:     "test-execution",
+				WorkflowVersion: workflowVersion,
+				Phase:           "RUNNING",
+				Outcome:         v1.SmokeTestOutcomeRunning,
+				StartTime:       metav1.NewTime(startTime),
+				PreviousLaunchPlans: []v1.LaunchPlanStatus{
+					{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: "0.9.0"},
+				},
+			},
+		}
+	}
+
+	t.Run("success case: smoke test is launched after the registration", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = smokeTestSpec
+				arg.Status.LaunchPlans = []v1.LaunchPlanStatus{
+					{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: "0.9.0"},
+				}
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		previous := meta
+		previous.WorkflowVersion = "0.9.0"
+		smokeTestFlyteAdminClient := fMocks.NewClient(t)
+		smokeTestFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+		smokeTestFlyteAdminClient.EXPECT().ActivateLaunchPlan(mock.Anything, "daily", meta, flyteAuth).Return(nil).Once()
+		smokeTestFlyteAdminClient.EXPECT().DeactivateLaunchPlan(mock.Anything, "daily", previous, flyteAuth).Return(nil).Once()
+		smokeTestFlyteAdminClient.EXPECT().CreateExecution(mock.Anything, flyte.ExecutionRequest{
+			LaunchPlan: "smoke",
+			Meta:       meta,
+			Inputs:     map[string]any{"count": float64(2)},
+		}, flyteAuth).Return("test-execution", nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: smokeTestFlyteAdminClient,
+			Config: internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint,
+				SmokeTestPollInterval: 30 * time.Second},
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{RequeueAfter: 30 * time.Second}, result)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeRegistered))
+		ready := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, v1.ReasonSmokeTestRunning, ready.Reason)
+		require.NotNil(t, status.SmokeTest)
+		assert.Equal(t, "test-execution", status.SmokeTest.ExecutionID)
+		assert.Equal(t, v1.SmokeTestOutcomeRunning, status.SmokeTest.Outcome)
+		assert.Equal(t, []v1.LaunchPlanStatus{
+			{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: "0.9.0"},
+		}, status.SmokeTest.PreviousLaunchPlans)
+		assert.Equal(t, smokeTestHash, status.LastRegisteredSpecHash)
+		assert.Equal(t, "Normal DownloadStarted Downloading test-uri version 1.0.0", <-recorder.Events)
+		assert.Equal(t, "Normal DownloadSucceeded downloaded test-uri version 1.0.0 with digest "+artifactDigest, <-recorder.Events)
+		assert.Equal(t, "Normal RegistrationSucceeded registered 0 entities with version 1.0.0 in test-project/test-domain", <-recorder.Events)
+		assert.Equal(t, "Normal LaunchPlanActivated Activated launch plan daily version 1.0.0 in test-project/test-domain", <-recorder.Events)
+		assert.Equal(t, "Normal LaunchPlanDeactivated Deactivated launch plan daily version 0.9.0 in test-project/test-domain", <-recorder.Events)
+		assert.Equal(t, "Normal SmokeTestStarted launched execution test-execution of launch plan smoke version 1.0.0 in test-project/test-domain", <-recorder.Events)
+	})
+
+	t.Run("success case: succeeded smoke test makes the registration ready", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Spec = smokeTestSpec
+				arg.Status = smokeTestStatus(time.Now())
+			}).Return(nil).Once()
+
+		smokeTestFlyteAdminClient := fMocks.NewClient(t)
+		smokeTestFlyteAdminClient.EXPECT().GetExecution(mock.Anything, workflowProject, workflowDomain, "test-execution", flyteAuth).
+			Return(flyte.Execution{Name: "test-execution", Phase: flyte.ExecutionPhaseSucceeded}, nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: smokeTestFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeSmokeTested))
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+		assert.Equal(t, v1.SmokeTestOutcomeSucceeded, status.SmokeTest.Outcome)
+		assert.Equal(t, flyte.ExecutionPhaseSucceeded, status.SmokeTest.Phase)
+		assert.NotNil(t, status.SmokeTest.CompletionTime)
+		assert.Equal(t, "Normal SmokeTestSucceeded execution test-execution of launch plan smoke version 1.0.0 succeeded", <-recorder.Events)
+	})
+
+	t.Run("success case: running smoke test is polled again", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Spec = smokeTestSpec
+				arg.Status = smokeTestStatus(time.Now())
+			}).Return(nil).Once()
+
+		smokeTestFlyteAdminClient := fMocks.NewClient(t)
+		smokeTestFlyteAdminClient.EXPECT().GetExecution(mock.Anything, workflowProject, workflowDomain, "test-execution", flyteAuth).
+			Return(flyte.Execution{Name: "test-execution", Phase: "RUNNING"}, nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: smokeTestFlyteAdminClient,
+			Config: internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint,
+				SmokeTestPollInterval: 30 * time.Second},
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{RequeueAfter: 30 * time.Second}, result)
+	})
+
+	t.Run("failure case: failed smoke test rolls back the launch plans", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Spec = smokeTestSpec
+				arg.Status = smokeTestStatus(time.Now())
+			}).Return(nil).Once()
+
+		previous := meta
+		previous.WorkflowVersion = "0.9.0"
+		smokeTestFlyteAdminClient := fMocks.NewClient(t)
+		smokeTestFlyteAdminClient.EXPECT().GetExecution(mock.Anything, workflowProject, workflowDomain, "test-execution", flyteAuth).
+			Return(flyte.Execution{Name: "test-execution", Phase: flyte.ExecutionPhaseFailed, Error: "division by zero"}, nil).Once()
+		smokeTestFlyteAdminClient.EXPECT().ActivateLaunchPlan(mock.Anything, "daily", previous, flyteAuth).Return(nil).Once()
+		smokeTestFlyteAdminClient.EXPECT().DeactivateLaunchPlan(mock.Anything, "daily", meta, flyteAuth).Return(nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: smokeTestFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		message := "execution test-execution of launch plan smoke version 1.0.0 ended in phase FAILED: division by zero"
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		ready := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, v1.ReasonSmokeTestFailed, ready.Reason)
+		assert.Equal(t, message, status.LastError)
+		assert.Equal(t, v1.SmokeTestOutcomeFailed, status.SmokeTest.Outcome)
+		assert.True(t, status.SmokeTest.RolledBack)
+		assert.Equal(t, []v1.LaunchPlanStatus{
+			{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: "0.9.0"},
+		}, status.LaunchPlans)
+		assert.Equal(t, "Normal LaunchPlansRolledBack Restored the launch plans to the versions active before version 1.0.0 was registered", <-recorder.Events)
+		assert.Equal(t, "Warning SmokeTestFailed "+message, <-recorder.Events)
+	})
+
+	t.Run("failure case: smoke test is terminated after the timeout", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		spec := smokeTestSpec
+		spec.SmokeTest = &v1.SmokeTestSpec{LaunchPlan: "smoke", Timeout: &metav1.Duration{Duration: time.Hour}}
+		hash, err := specHash(spec, "")
+		require.NoError(t, err)
+
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Spec = spec
+				arg.Status = smokeTestStatus(time.Now().Add(-2 * time.Hour))
+				arg.Status.LastRegisteredSpecHash = hash
+			}).Return(nil).Once()
+
+		smokeTestFlyteAdminClient := fMocks.NewClient(t)
+		smokeTestFlyteAdminClient.EXPECT().GetExecution(mock.Anything, workflowProject, workflowDomain, "test-execution", flyteAuth).
+			Return(flyte.Execution{Name: "test-execution", Phase: "RUNNING"}, nil).Once()
+		smokeTestFlyteAdminClient.EXPECT().TerminateExecution(mock.Anything, workflowProject, workflowDomain, "test-execution", flyteAuth).
+			Return(nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: smokeTestFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err = reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, v1.SmokeTestOutcomeTimedOut, status.SmokeTest.Outcome)
+		assert.False(t, status.SmokeTest.RolledBack)
+		assert.Equal(t, "execution test-execution of launch plan smoke version 1.0.0 did not complete within 1h0m0s", status.LastError)
+		assert.False(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+	})
+
+	t.Run("failure case: failed smoke test is rolled back to the last version registered successfully", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		spec := smokeTestSpec
+		spec.RollbackOnFailure = true
+		spec.SmokeTest = &v1.SmokeTestSpec{LaunchPlan: "smoke"}
+		hash, err := specHash(spec, "")
+		require.NoError(t, err)
+
+		lastGood := []v1.LaunchPlanStatus{{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: "0.8.0"}}
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Spec = spec
+				arg.Status = smokeTestStatus(time.Now())
+				arg.Status.LastRegisteredSpecHash = hash
+				arg.Status.History = []v1.RegisteredVersion{{WorkflowVersion: "0.8.0", LaunchPlans: lastGood}}
+			}).Return(nil).Once()
+
+		restored := meta
+		restored.WorkflowVersion = "0.8.0"
+		smokeTestFlyteAdminClient := fMocks.NewClient(t)
+		smokeTestFlyteAdminClient.EXPECT().GetExecution(mock.Anything, workflowProject, workflowDomain, "test-execution", flyteAuth).
+			Return(flyte.Execution{Name: "test-execution", Phase: flyte.ExecutionPhaseAborted}, nil).Once()
+		smokeTestFlyteAdminClient.EXPECT().ActivateLaunchPlan(mock.Anything, "daily", restored, flyteAuth).Return(nil).Once()
+		smokeTestFlyteAdminClient.EXPECT().DeactivateLaunchPlan(mock.Anything, "daily", meta, flyteAuth).Return(nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: smokeTestFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err = reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, lastGood, status.LaunchPlans)
+		assert.True(t, status.SmokeTest.RolledBack)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeDegraded))
+		assert.Equal(t, "Warning RolledBack version 1.0.0 failed, rolled back to version 0.8.0: execution test-execution of launch plan smoke version 1.0.0 ended in phase ABORTED", <-recorder.Events)
+		assert.Equal(t, "Warning SmokeTestFailed execution test-execution of launch plan smoke version 1.0.0 ended in phase ABORTED", <-recorder.Events)
+	})
+
+	t.Run("failure case: failed registration is rolled back to the last version registered successfully", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		lastGood := []v1.LaunchPlanStatus{{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: "0.9.0"}}
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+				arg.Spec.RollbackOnFailure = true
+				arg.Spec.LaunchPlans = []v1.LaunchPlanSpec{{Name: "daily", State: v1.LaunchPlanStateActive}}
+				arg.Status.LaunchPlans = lastGood
+				arg.Status.History = []v1.RegisteredVersion{{WorkflowVersion: "0.9.0", LaunchPlans: lastGood}}
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		rejected := fmt.Errorf("failed to execute flytectl: exit status 1, %w: daily.pb: invalid", flyte.ErrRegistrationRejected)
+		rollbackFlyteAdminClient := fMocks.NewClient(t)
+		rollbackFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, rejected).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: rollbackFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		assert.Equal(t, lastGood, status.LaunchPlans)
+		degraded := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeDegraded)
+		require.NotNil(t, degraded)
+		assert.Equal(t, metav1.ConditionTrue, degraded.Status)
+		assert.Equal(t, v1.ReasonRolledBack, degraded.Reason)
+		assert.Equal(t, "version 1.0.0 failed, rolled back to version 0.9.0: failed to register workflow failed to execute flytectl: exit status 1, failed entities: daily.pb: invalid", degraded.Message)
+		assert.False(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+		require.NotNil(t, status.Rollback)
+		assert.Equal(t, workflowVersion, status.Rollback.FailedVersion)
+		assert.Equal(t, "0.9.0", status.Rollback.RestoredVersion)
+		assert.Equal(t, []v1.RegisteredVersion{{WorkflowVersion: "0.9.0", LaunchPlans: lastGood}}, status.History)
+	})
+
+	t.Run("success case: transient failure is retried instead of being rolled back", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		lastGood := []v1.LaunchPlanStatus{{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: "0.9.0"}}
+		spec := registeredSpec
+		spec.RollbackOnFailure = true
+		spec.LaunchPlans = []v1.LaunchPlanSpec{{Name: "daily", State: v1.LaunchPlanStateActive}}
+
+		var status v1.FlyteRegistrationStatus
+		status.LaunchPlans = lastGood
+		status.History = []v1.RegisteredVersion{{WorkflowVersion: "0.9.0", LaunchPlans: lastGood}}
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Times(2).
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = spec
+				arg.Status = status
+			}).Return(nil)
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Times(2)
+
+		previous := meta
+		previous.WorkflowVersion = "0.9.0"
+		retryFlyteAdminClient := fMocks.NewClient(t)
+		retryFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Times(2)
+		// The first attempt fails to deactivate the previous launch plan, the launch plans are restored
+		retryFlyteAdminClient.EXPECT().ActivateLaunchPlan(mock.Anything, "daily", meta, flyteAuth).Return(nil).Once()
+		retryFlyteAdminClient.EXPECT().DeactivateLaunchPlan(mock.Anything, "daily", previous, flyteAuth).Return(errors.New("503 service unavailable")).Once()
+		retryFlyteAdminClient.EXPECT().ActivateLaunchPlan(mock.Anything, "daily", previous, flyteAuth).Return(nil).Once()
+		retryFlyteAdminClient.EXPECT().DeactivateLaunchPlan(mock.Anything, "daily", meta, flyteAuth).Return(nil).Once()
+		// The retry succeeds
+		retryFlyteAdminClient.EXPECT().ActivateLaunchPlan(mock.Anything, "daily", meta, flyteAuth).Return(nil).Once()
+		retryFlyteAdminClient.EXPECT().DeactivateLaunchPlan(mock.Anything, "daily", previous, flyteAuth).Return(nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Times(2)
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = *obj.(*v1.FlyteRegistration).Status.DeepCopy()
+			}).Return(nil).Times(2)
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(20),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: retryFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "503 service unavailable")
+		assert.Nil(t, status.Rollback)
+		assert.Equal(t, lastGood, status.LaunchPlans)
+		assert.False(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+
+		// EXECUTION
+		_, err = reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeReady))
+		assert.Equal(t, workflowVersion, status.History[0].WorkflowVersion)
+		assert.Equal(t, []v1.LaunchPlanStatus{{Project: workflowProject, Domain: workflowDomain, Name: "daily", ActiveVersion: workflowVersion}}, status.LaunchPlans)
+	})
+
+	t.Run("success case: rolled back version is not registered again", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Spec = registeredSpec
+				arg.Status.ObservedGeneration = 2
+				arg.Status.Rollback = &v1.RollbackStatus{FailedVersion: workflowVersion, FailedSpecHash: registeredHash, RestoredVersion: "0.9.0"}
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: fMocks.NewClient(t),
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+	})
+
+	t.Run("success case: registered version is recorded in the history", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec = registeredSpec
+				arg.Status.History = []v1.RegisteredVersion{{WorkflowVersion: "0.9.0"}}
+				arg.Status.Rollback = &v1.RollbackStatus{FailedVersion: "0.9.1", RestoredVersion: "0.9.0"}
+				arg.Status.Conditions = []metav1.Condition{{Type: v1.ConditionTypeDegraded, Status: metav1.ConditionTrue, Reason: v1.ReasonRolledBack}}
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		require.Len(t, status.History, 2)
+		assert.Equal(t, workflowVersion, status.History[0].WorkflowVersion)
+		assert.Equal(t, artifactDigest, status.History[0].Digest)
+		assert.Equal(t, "0.9.0", status.History[1].WorkflowVersion)
+		assert.Nil(t, status.Rollback)
+		assert.Nil(t, apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeDegraded))
+	})
+
+	t.Run("success case: registration waits for the project", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Namespace = req.Namespace
+				arg.Spec = registeredSpec
+				arg.Spec.WaitForProject = true
+			}).Return(nil).Once()
+
+		projectKey := client.ObjectKey{Name: workflowProject}
+		mockK8sClient.EXPECT().Get(mock.Anything, projectKey, mock.AnythingOfType("*v1.FlyteProject")).
+			Return(apierrors.NewNotFound(v1.GroupVersion.WithResource("flyteprojects").GroupResource(), workflowProject)).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       dMocks.NewClient(t),
+			FlyteAdminClient: fMocks.NewClient(t),
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		result, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		condition := apimeta.FindStatusCondition(status.Conditions, v1.ConditionTypeReady)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, v1.ReasonProjectNotReady, condition.Reason)
+		assert.Equal(t, "FlyteProject test-project does not exist", condition.Message)
+		assert.Equal(t, "Normal ProjectNotReady Waiting to register: FlyteProject test-project does not exist", <-recorder.Events)
+	})
+
+	t.Run("success case: registration is registered once the project is ready", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Namespace = req.Namespace
+				arg.Spec = registeredSpec
+				arg.Spec.WaitForProject = true
+			}).Return(nil).Once()
+
+		projectKey := client.ObjectKey{Name: workflowProject}
+		mockK8sClient.EXPECT().Get(mock.Anything, projectKey, mock.AnythingOfType("*v1.FlyteProject")).
+			Run(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) {
+				project := obj.(*v1.FlyteProject)
+				project.Generation = 1
+				project.Status.ObservedGeneration = 1
+				project.Status.Conditions = []metav1.Condition{{Type: v1.ConditionTypeReady, Status: metav1.ConditionTrue}}
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("success case: archive deletion policy adds the finalizer", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Generation = 2
+				arg.Spec = registeredSpec
+				arg.Spec.DeletionPolicy = v1.DeletionPolicyArchive
+			}).Return(nil).Once()
+
+		var finalizers []string
+		mockK8sClient.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.UpdateOption) {
+				finalizers = obj.GetFinalizers()
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, []string{v1.Finalizer}, finalizers)
+	})
+
+	t.Run("success case: deletion archives the registered version and removes the finalizer", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		deletionTimestamp := metav1.Now()
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.DeletionTimestamp = &deletionTimestamp
+				arg.Finalizers = []string{v1.Finalizer}
+				arg.Spec = registeredSpec
+				arg.Spec.DeletionPolicy = v1.DeletionPolicyArchive
+				arg.Status.WorkflowVersion = workflowVersion
+				arg.Status.WorkflowDomain = workflowDomain
+				arg.Status.WorkflowProject = workflowProject
+				arg.Status.RegisteredEntities = &v1.RegisteredEntities{
+					LaunchPlans: []string{"test-launchplan"},
+					Workflows:   []string{"test-workflow", "shared-workflow"},
+				}
+			}).Return(nil).Once()
+
+		// The workflows other registrations use in the same project and domain are not archived
+		mockK8sClient.EXPECT().List(mock.Anything, mock.AnythingOfType("*v1.FlyteRegistrationList")).
+			Run(func(_ context.Context, list client.ObjectList, _ ...client.ListOption) {
+				list.(*v1.FlyteRegistrationList).Items = []v1.FlyteRegistration{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "shared", UID: "shared-uid"},
+						Status: v1.FlyteRegistrationStatus{WorkflowVersion: "2.0.0", WorkflowDomain: workflowDomain, WorkflowProject: workflowProject,
+							RegisteredEntities: &v1.RegisteredEntities{Workflows: []string{"shared-workflow"}}},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "other-domain", UID: "other-domain-uid"},
+						Status: v1.FlyteRegistrationStatus{WorkflowVersion: "2.0.0", WorkflowDomain: "other-domain", WorkflowProject: workflowProject,
+							RegisteredEntities: &v1.RegisteredEntities{Workflows: []string{"other-workflow"}}},
+					},
+				}
+			}).Return(nil).Once()
+
+		mockFlyteAdminClient.EXPECT().ArchiveWorkflow(mock.Anything, meta, []string{"test-launchplan"}, []string{"test-workflow"}, flyteAuth).Return(nil).Once()
+
+		var removed bool
+		mockK8sClient.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.UpdateOption) {
+				removed = !controllerutil.ContainsFinalizer(obj, v1.Finalizer)
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.True(t, removed)
+	})
+
+	t.Run("success case: deletion leaves the entities of a registration with the same version untouched", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		deletionTimestamp := metav1.Now()
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.DeletionTimestamp = &deletionTimestamp
+				arg.Finalizers = []string{v1.Finalizer}
+				arg.Spec = registeredSpec
+				arg.Spec.DeletionPolicy = v1.DeletionPolicyArchive
+				arg.Status.WorkflowVersion = workflowVersion
+				arg.Status.WorkflowDomain = workflowDomain
+				arg.Status.WorkflowProject = workflowProject
+				arg.Status.RegisteredEntities = &v1.RegisteredEntities{
+					LaunchPlans: []string{"test-launchplan"},
+					Workflows:   []string{"test-workflow"},
+				}
+			}).Return(nil).Once()
+
+		// The other registration registered a different workflow package with the same version into the same project
+		// and domain
+		mockK8sClient.EXPECT().List(mock.Anything, mock.AnythingOfType("*v1.FlyteRegistrationList")).
+			Run(func(_ context.Context, list client.ObjectList, _ ...client.ListOption) {
+				list.(*v1.FlyteRegistrationList).Items = []v1.FlyteRegistration{
+					{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "same-version", UID: "same-version-uid"},
+						Status: v1.FlyteRegistrationStatus{WorkflowVersion: workflowVersion, WorkflowDomain: workflowDomain, WorkflowProject: workflowProject,
+							RegisteredEntities: &v1.RegisteredEntities{
+								LaunchPlans: []string{"other-launchplan"},
+								Workflows:   []string{"other-workflow"},
+							}},
+					},
+				}
+			}).Return(nil).Once()
+
+		// Only the launch plans and workflows of the deleted registration are passed on to be archived
+		mockFlyteAdminClient.EXPECT().ArchiveWorkflow(mock.Anything, meta, []string{"test-launchplan"}, []string{"test-workflow"}, flyteAuth).Return(nil).Once()
+
+		mockK8sClient.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("success case: deletion archives the registered version of every target", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		deletionTimestamp := metav1.Now()
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.DeletionTimestamp = &deletionTimestamp
+				arg.Finalizers = []string{v1.Finalizer}
+				arg.Spec = registeredSpec
+				arg.Spec.DeletionPolicy = v1.DeletionPolicyArchive
+				arg.Status.Targets = []v1.TargetStatus{
+					{Project: workflowProject, Domain: "staging", Ready: true, WorkflowVersion: workflowVersion},
+					{Project: workflowProject, Domain: "production", Ready: false, WorkflowVersion: "0.9.0"},
+					{Project: workflowProject, Domain: "development", Ready: false},
+				}
+			}).Return(nil).Once()
+
+		mockK8sClient.EXPECT().List(mock.Anything, mock.AnythingOfType("*v1.FlyteRegistrationList")).Return(nil).Twice()
+
+		targetFlyteAdminClient := fMocks.NewClient(t)
+		targetFlyteAdminClient.EXPECT().ArchiveWorkflow(mock.Anything,
+			flyte.WorkflowMetadata{WorkflowVersion: workflowVersion, Domain: "staging", Project: workflowProject}, []string(nil), []string(nil), flyteAuth).Return(nil).Once()
+		targetFlyteAdminClient.EXPECT().ArchiveWorkflow(mock.Anything,
+			flyte.WorkflowMetadata{WorkflowVersion: "0.9.0", Domain: "production", Project: workflowProject}, []string(nil), []string(nil), flyteAuth).Return(nil).Once()
+
+		mockK8sClient.EXPECT().Update(mock.Anything, mock.Anything).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: targetFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: deletion keeps the finalizer when archiving fails", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		deletionTimestamp := metav1.Now()
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.DeletionTimestamp = &deletionTimestamp
+				arg.Finalizers = []string{v1.Finalizer}
+				arg.Spec = registeredSpec
+				arg.Spec.DeletionPolicy = v1.DeletionPolicyArchive
+				arg.Status.WorkflowVersion = workflowVersion
+				arg.Status.WorkflowDomain = workflowDomain
+				arg.Status.WorkflowProject = workflowProject
+			}).Return(nil).Once()
+
+		mockK8sClient.EXPECT().List(mock.Anything, mock.AnythingOfType("*v1.FlyteRegistrationList")).Return(nil).Once()
+
+		mockFlyteAdminClient.EXPECT().ArchiveWorkflow(mock.Anything, meta, []string(nil), []string(nil), flyteAuth).Return(errors.New("test error")).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to archive workflow")
+	})
+
+	t.Run("failure case: k8s client status update", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec.WorkflowVersion = workflowVersion
+				arg.Spec.WorkflowDomain = workflowDomain
+				arg.Spec.WorkflowProject = workflowProject
+				arg.Spec.WorkflowPackageURI = workflowPackageURI
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, nil).Once()
+
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).Return(errors.New("test error")).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to update status")
+	})
+
+	t.Run("failure case: k8s client get method", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec.WorkflowVersion = workflowVersion
+				arg.Spec.WorkflowDomain = workflowDomain
+				arg.Spec.WorkflowProject = workflowProject
+				arg.Spec.WorkflowPackageURI = workflowPackageURI
+			}).Return(errors.New("test error")).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "test error")
+	})
+
+	t.Run("failure case: downloader downloadArtifact method", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec.WorkflowVersion = workflowVersion
+				arg.Spec.WorkflowDomain = workflowDomain
+				arg.Spec.WorkflowProject = workflowProject
+				arg.Spec.WorkflowPackageURI = workflowPackageURI
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(internal.Artifact{}, errors.New("test error")).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         record.NewFakeRecorder(10),
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to download artifact")
+		assert.True(t, apimeta.IsStatusConditionFalse(status.Conditions, v1.ConditionTypeDownloaded))
+		assert.True(t, apimeta.IsStatusConditionFalse(status.Conditions, v1.ConditionTypeReady))
+		assert.Contains(t, status.LastError, "test error")
+	})
+
+	t.Run("failure case: flyte client registerWorkflow", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockK8sClient.EXPECT().Get(mock.Anything, req.NamespacedName, &testWorkflow).Once().
+			Run(func(args mock.Arguments) {
+				arg := args.Get(2).(*v1.FlyteRegistration)
+				arg.Spec.WorkflowVersion = workflowVersion
+				arg.Spec.WorkflowDomain = workflowDomain
+				arg.Spec.WorkflowProject = workflowProject
+				arg.Spec.WorkflowPackageURI = workflowPackageURI
+			}).Return(nil).Once()
+
+		mockDownloader.EXPECT().DownloadArtifact(mock.Anything, downloadRequest(workflowPackageURI, noCredentials)).Return(artifact, nil).Once()
+
+		mockFlyteAdminClient.EXPECT().RegisterWorkflow(mock.Anything, artifactPath, meta, flyteAuth).Return(nil, errors.New("test error")).Once()
+
+		var status v1.FlyteRegistrationStatus
+		mockK8sClient.EXPECT().Status().Return(mockStatusWriter).Once()
+		mockStatusWriter.EXPECT().Update(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) {
+				status = obj.(*v1.FlyteRegistration).Status
+			}).Return(nil).Once()
+
+		// EXECUTION
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &FlyteRegistrationReconciler{
+			K8sClient:        mockK8sClient,
+			Recorder:         recorder,
+			Workspaces:       workspaces,
+			Downloader:       mockDownloader,
+			FlyteAdminClient: mockFlyteAdminClient,
+			Config:           internal.Config{FlyteClientID: flyteClientID, FlyteAdminEndpoint: flyteAdminEndpoint},
+		}
+
+		_, err := reconciler.Reconcile(context.Background(), req)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to register workflow")
+		assert.True(t, apimeta.IsStatusConditionTrue(status.Conditions, v1.ConditionTypeDownloaded))
+		assert.True(t, apimeta.IsStatusConditionFalse(status.Conditions, v1.ConditionTypeRegistered))
+		assert.True(t, apimeta.IsStatusConditionFalse(status.Conditions, v1.ConditionTypeReady))
+		assert.Equal(t, "Normal DownloadStarted Downloading test-uri version 1.0.0", <-recorder.Events)
+		assert.Equal(t, "Normal DownloadSucceeded downloaded test-uri version 1.0.0 with digest "+artifactDigest, <-recorder.Events)
+		assert.Equal(t, "Warning RegistrationFailed failed to register workflow test error", <-recorder.Events)
+	})
+}
+
+func TestTruncateMessage(t *testing.T) {
+	assert.Equal(t, "short message", truncateMessage("short message"))
+
+	message := truncateMessage(strings.Repeat("flytectl output ", 100))
+	assert.Len(t, message, maxEventMessageLength)
+	assert.True(t, strings.HasSuffix(message, "... (truncated)"))
+}
+
+func TestRegisteredEntities(t *testing.T) {
+	t.Run("failed entities are left out", func(t *testing.T) {
+		results := []flyte.RegistrationResult{
+			{Entity: flyte.Identifier{ResourceType: flyte.ResourceTypeTask, Name: "test-task"}, Succeeded: true},
+			{Entity: flyte.Identifier{ResourceType: flyte.ResourceTypeTask, Name: "failed-task"}, Succeeded: false},
+		}
+
+		entities := registeredEntities(results)
+
+		assert.Equal(t, &v1.RegisteredEntities{Count: 1, Tasks: []string{"test-task"}}, entities)
+	})
+
+	t.Run("lists are capped", func(t *testing.T) {
+		results := make([]flyte.RegistrationResult, 0, maxRegisteredEntities+1)
+		for i := 0; i <= maxRegisteredEntities; i++ {
+			results = append(results, flyte.RegistrationResult{
+				Entity:    flyte.Identifier{ResourceType: flyte.ResourceTypeTask, Name: fmt.Sprintf("task-%d", i)},
+				Succeeded: true,
+			})
+		}
+
+		entities := registeredEntities(results)
+
+		assert.Equal(t, maxRegisteredEntities+1, entities.Count)
+		assert.Len(t, entities.Tasks, maxRegisteredEntities)
+		assert.True(t, entities.Truncated)
+	})
+}
+
+func TestRecordReadyVersion(t *testing.T) {
+	t.Run("earlier entry of the same version is replaced", func(t *testing.T) {
+		flyteWorkflow := &v1.FlyteRegistration{Status: v1.FlyteRegistrationStatus{
+			History: []v1.RegisteredVersion{{WorkflowVersion: "1.1.0"}, {WorkflowVersion: "1.0.0", Digest: "old"}},
+		}}
+
+		recordReadyVersion(flyteWorkflow, v1.RegisteredVersion{WorkflowVersion: "1.0.0", Digest: "new"})
+
+		assert.Equal(t, []v1.RegisteredVersion{
+			{WorkflowVersion: "1.0.0", Digest: "new"},
+			{WorkflowVersion: "1.1.0"},
+		}, flyteWorkflow.Status.History)
+	})
+
+	t.Run("history is capped", func(t *testing.T) {
+		flyteWorkflow := &v1.FlyteRegistration{}
+		for i := 0; i <= maxVersionHistory; i++ {
+			recordReadyVersion(flyteWorkflow, v1.RegisteredVersion{WorkflowVersion: fmt.Sprintf("1.%d.0", i)})
+		}
+
+		assert.Len(t, flyteWorkflow.Status.History, maxVersionHistory)
+		assert.Equal(t, fmt.Sprintf("1.%d.0", maxVersionHistory), flyteWorkflow.Status.History[0].WorkflowVersion)
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("failures of an object are retried with exponential backoff", func(t *testing.T) {
+		limiter := rateLimiter(internal.Config{ReconcileBaseBackoff: time.Second, ReconcileMaxBackoff: 4 * time.Second})
+
+		assert.Equal(t, time.Second, limiter.When("test"))
+		assert.Equal(t, 2*time.Second, limiter.When("test"))
+		assert.Equal(t, 4*time.Second, limiter.When("test"))
+		assert.Equal(t, 4*time.Second, limiter.When("test"))
+	})
+
+	t.Run("the queue is held to the overall rate once the burst is used up", func(t *testing.T) {
+		limiter := rateLimiter(internal.Config{ReconcileBaseBackoff: time.Millisecond, ReconcileMaxBackoff: time.Second})
+		for i := 0; i < 100; i++ {
+			limiter.When(fmt.Sprintf("test-%d", i))
+		}
+
+		assert.Greater(t, limiter.When("test"), 50*time.Millisecond)
+	})
+}
+
+func TestRegistrationsForProject(t *testing.T) {
+	// MOCK BEHAVIOUR
+	mockK8sClient := mocks.NewK8sClient(t)
+	mockK8sClient.EXPECT().List(mock.Anything, mock.AnythingOfType("*v1.FlyteRegistrationList")).
+		Run(func(_ context.Context, list client.ObjectList, _ ...client.ListOption) {
+			list.(*v1.FlyteRegistrationList).Items = []v1.FlyteRegistration{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "waiting"},
+					Spec:       v1.FlyteRegistrationSpec{WorkflowProject: "test-project", WaitForProject: true},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "not-waiting"},
+					Spec:       v1.FlyteRegistrationSpec{WorkflowProject: "test-project"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "other-project"},
+					Spec:       v1.FlyteRegistrationSpec{WorkflowProject: "other-project", WaitForProject: true},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "target-project"},
+					Spec: v1.FlyteRegistrationSpec{WorkflowProject: "other-project", WaitForProject: true,
+						Targets: []v1.RegistrationTarget{{Domain: "development"}, {Domain: "production", Project: "test-project"}}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "waiting"},
+					Spec:       v1.FlyteRegistrationSpec{WorkflowProject: "test-project", WaitForProject: true},
+				},
+			}
+		}).Return(nil).Once()
+
+	// EXECUTION
+	reconciler := &FlyteRegistrationReconciler{K8sClient: mockK8sClient}
+
+	project := &v1.FlyteProject{ObjectMeta: metav1.ObjectMeta{Name: "test-project"}}
+	requests := reconciler.registrationsForProject(context.Background(), project)
+
+	// ASSERTIONS
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "test", Name: "waiting"}},
+		{NamespacedName: types.NamespacedName{Namespace: "test", Name: "target-project"}},
+		{NamespacedName: types.NamespacedName{Namespace: "other", Name: "waiting"}},
+	}, requests)
+}
+
+func TestSpecHash(t *testing.T) {
+	spec := v1.FlyteRegistrationSpec{
+		WorkflowVersion:    "1.0.0",
+		WorkflowDomain:     "development",
+		WorkflowProject:    "test-project",
+		WorkflowPackageURI: "adarga/example-workflow",
+	}
+	hash, err := specHash(spec, "oci")
+	require.NoError(t, err)
+
+	t.Run("defaulted source type does not change the hash", func(t *testing.T) {
+		defaulted := spec
+		defaulted.Source = &v1.SourceSpec{Type: "oci"}
+
+		defaultedHash, err := specHash(defaulted, "oci")
+
+		assert.NoError(t, err)
+		assert.Equal(t, hash, defaultedHash)
+	})
+
+	t.Run("source type of another strategy changes the hash", func(t *testing.T) {
+		changed := spec
+		changed.Source = &v1.SourceSpec{Type: "jfrog"}
+
+		changedHash, err := specHash(changed, "oci")
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, hash, changedHash)
+	})
+
+	t.Run("source type is left out next to the other source fields", func(t *testing.T) {
+		withCredentials := spec
+		withCredentials.Source = &v1.SourceSpec{CredentialsSecretRef: &corev1.LocalObjectReference{Name: "test-secret"}}
+		defaulted := withCredentials
+		defaulted.Source = &v1.SourceSpec{Type: "oci", CredentialsSecretRef: withCredentials.Source.CredentialsSecretRef}
+
+		withCredentialsHash, err := specHash(withCredentials, "oci")
+		require.NoError(t, err)
+		defaultedHash, err := specHash(defaulted, "oci")
+
+		assert.NoError(t, err)
+		assert.Equal(t, withCredentialsHash, defaultedHash)
+	})
+}
+
+func TestRegistrationChanged(t *testing.T) {
+	// SHARED INPUTS
+	registration := v1.FlyteRegistration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "test", Generation: 1, ResourceVersion: "1"},
+		Spec:       v1.FlyteRegistrationSpec{WorkflowVersion: "1.0.0"},
+	}
+
+	t.Run("status update is not reconciled", func(t *testing.T) {
+		// EXECUTION
+		updated := registration.DeepCopy()
+		updated.ResourceVersion = "2"
+		now := metav1.Now()
+		updated.Status.LastAttemptTime = &now
+		updated.Status.LastError = "test error"
+
+		// ASSERTIONS
+		assert.False(t, registrationChanged.Update(event.UpdateEvent{ObjectOld: &registration, ObjectNew: updated}))
+	})
+
+	t.Run("spec update is reconciled", func(t *testing.T) {
+		// EXECUTION
+		updated := registration.DeepCopy()
+		updated.ResourceVersion = "2"
+		updated.Generation = 2
+		updated.Spec.WorkflowVersion = "1.0.1"
+
+		// ASSERTIONS
+		assert.True(t, registrationChanged.Update(event.UpdateEvent{ObjectOld: &registration, ObjectNew: updated}))
+	})
+
+	t.Run("annotation update is reconciled", func(t *testing.T) {
+		// EXECUTION
+		updated := registration.DeepCopy()
+		updated.ResourceVersion = "2"
+		updated.Annotations = map[string]string{v1.ReconcileRequestedAtAnnotation: "2025-01-02T00:00:00Z"}
+
+		// ASSERTIONS
+		assert.True(t, registrationChanged.Update(event.UpdateEvent{ObjectOld: &registration, ObjectNew: updated}))
 	})
 }
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
+)
+
+// updateLaunchPlans activates or deactivates the launch plans of the spec in a target the workflow package has just
+// been registered into, and returns their state in the target. The version of a launch plan that was active before is
+// deactivated once another version is activated, and along with the registered version when the launch plan is
+// deactivated. On failure the launch plans that were not updated keep their previous state.
+func (r *FlyteRegistrationReconciler) updateLaunchPlans(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, t target, flyteAuth flyte.Auth) ([]v1.LaunchPlanStatus, error) {
+	statuses := previousLaunchPlans(flyteWorkflow, t)
+
+	for i, launchPlan := range flyteWorkflow.Spec.LaunchPlans {
+		meta := flyte.WorkflowMetadata{WorkflowVersion: t.version, Domain: t.domain, Project: t.project}
+		previous := meta
+		previous.WorkflowVersion = statuses[i].ActiveVersion
+		deactivatePrevious := previous.WorkflowVersion != "" && previous.WorkflowVersion != t.version
+
+		if launchPlan.State == v1.LaunchPlanStateInactive {
+			if err := r.FlyteAdminClient.DeactivateLaunchPlan(ctx, launchPlan.Name, meta, flyteAuth); err != nil {
+				return statuses, err
+			}
+			if deactivatePrevious {
+				if err := r.FlyteAdminClient.DeactivateLaunchPlan(ctx, launchPlan.Name, previous, flyteAuth); err != nil {
+					return statuses, err
+				}
+			}
+			statuses[i].ActiveVersion = ""
+			r.Recorder.Eventf(flyteWorkflow, corev1.EventTypeNormal, EventReasonLaunchPlanDeactivated,
+				"Deactivated launch plan %s in %s/%s", launchPlan.Name, t.project, t.domain)
+			continue
+		}
+
+		if err := r.FlyteAdminClient.ActivateLaunchPlan(ctx, launchPlan.Name, meta, flyteAuth); err != nil {
+			return statuses, err
+		}
+		statuses[i].ActiveVersion = t.version
+		r.Recorder.Eventf(flyteWorkflow, corev1.EventTypeNormal, EventReasonLaunchPlanActivated,
+			"Activated launch plan %s version %s in %s/%s", launchPlan.Name, t.version, t.project, t.domain)
+
+		if deactivatePrevious {
+			if err := r.FlyteAdminClient.DeactivateLaunchPlan(ctx, launchPlan.Name, previous, flyteAuth); err != nil {
+				return statuses, err
+			}
+			r.Recorder.Eventf(flyteWorkflow, corev1.EventTypeNormal, EventReasonLaunchPlanDeactivated,
+				"Deactivated launch plan %s version %s in %s/%s", launchPlan.Name, previous.WorkflowVersion, t.project, t.domain)
+		}
+	}
+
+	return statuses, nil
+}
+
+// restoreLaunchPlans puts the launch plans of the status back in the given state, the launch plans without a state to
+// restore are deactivated. The status is updated as each launch plan is restored, so that a failed restore is resumed
+// where it stopped.
+func (r *FlyteRegistrationReconciler) restoreLaunchPlans(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, restore []v1.LaunchPlanStatus, flyteAuth flyte.Auth) error {
+	for i, current := range flyteWorkflow.Status.LaunchPlans {
+		restored := current
+		restored.ActiveVersion = ""
+		for _, previous := range restore {
+			if previous.Project == current.Project && previous.Domain == current.Domain && previous.Name == current.Name {
+				restored.ActiveVersion = previous.ActiveVersion
+				break
+			}
+		}
+		if restored.ActiveVersion == current.ActiveVersion {
+			continue
+		}
+
+		if restored.ActiveVersion != "" {
+			meta := flyte.WorkflowMetadata{WorkflowVersion: restored.ActiveVersion, Domain: current.Domain, Project: current.Project}
+			if err := r.FlyteAdminClient.ActivateLaunchPlan(ctx, current.Name, meta, flyteAuth); err != nil {
+				return err
+			}
+		}
+		if current.ActiveVersion != "" {
+			meta := flyte.WorkflowMetadata{WorkflowVersion: current.ActiveVersion, Domain: current.Domain, Project: current.Project}
+			if err := r.FlyteAdminClient.DeactivateLaunchPlan(ctx, current.Name, meta, flyteAuth); err != nil {
+				return err
+			}
+		}
+		flyteWorkflow.Status.LaunchPlans[i] = restored
+	}
+
+	return nil
+}
+
+// previousLaunchPlans returns the state of the launch plans of the spec in a target as it was last recorded on the
+// status, in the order of the spec
+func previousLaunchPlans(flyteWorkflow *v1.FlyteRegistration, t target) []v1.LaunchPlanStatus {
+	statuses := make([]v1.LaunchPlanStatus, 0, len(flyteWorkflow.Spec.LaunchPlans))
+	for _, launchPlan := range flyteWorkflow.Spec.LaunchPlans {
+		status := v1.LaunchPlanStatus{Project: t.project, Domain: t.domain, Name: launchPlan.Name}
+		for _, previous := range flyteWorkflow.Status.LaunchPlans {
+			if previous.Project == t.project && previous.Domain == t.domain && previous.Name == launchPlan.Name {
+				status.ActiveVersion = previous.ActiveVersion
+				break
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
+)
+
+// maxVersionHistory caps the number of versions registered successfully that are recorded on the status
+const maxVersionHistory = 10
+
+// rollBack restores the launch plans of the spec to the last version registered successfully after a version failed,
+// and reports the Degraded condition naming the failed version. The caller records the failure itself.
+func (r *FlyteRegistrationReconciler) rollBack(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, failedVersion string, hash string, cause string, flyteAuth flyte.Auth) error {
+	lastGood := flyteWorkflow.Status.History[0]
+	if err := r.restoreLaunchPlans(ctx, flyteWorkflow, lastGood.LaunchPlans, flyteAuth); err != nil {
+		return err
+	}
+
+	flyteWorkflow.Status.Rollback = &v1.RollbackStatus{
+		FailedVersion:   failedVersion,
+		FailedSpecHash:  hash,
+		RestoredVersion: lastGood.WorkflowVersion,
+		Time:            metav1.Now(),
+	}
+
+	message := fmt.Sprintf("version %s failed, rolled back to version %s: %s", failedVersion, lastGood.WorkflowVersion, cause)
+	setCondition(flyteWorkflow, v1.ConditionTypeDegraded, metav1.ConditionTrue, v1.ReasonRolledBack, message)
+	r.Recorder.Event(flyteWorkflow, corev1.EventTypeWarning, EventReasonRolledBack, truncateMessage(message))
+
+	return nil
+}
+
+// recordReadyVersion records a version that became Ready at the top of the history of the status, replacing an earlier
+// entry of the same version, and clears the rollback of a version that failed before
+func recordReadyVersion(flyteWorkflow *v1.FlyteRegistration, registered v1.RegisteredVersion) {
+	// The launch plans of the status are restored in place on rollback, the history keeps a copy of them
+	registered.LaunchPlans = append([]v1.LaunchPlanStatus(nil), registered.LaunchPlans...)
+
+	history := []v1.RegisteredVersion{registered}
+	for _, previous := range flyteWorkflow.Status.History {
+		if len(history) >= maxVersionHistory {
+			break
+		}
+		if previous.WorkflowVersion != registered.WorkflowVersion {
+			history = append(history, previous)
+		}
+	}
+	flyteWorkflow.Status.History = history
+
+	flyteWorkflow.Status.Rollback = nil
+	apimeta.RemoveStatusCondition(&flyteWorkflow.Status.Conditions, v1.ConditionTypeDegraded)
+}
+
+// canRollBack returns true when the spec asks for failed versions to be rolled back and there is a version registered
+// successfully to roll back to
+func canRollBack(flyteWorkflow *v1.FlyteRegistration) bool {
+	return flyteWorkflow.Spec.RollbackOnFailure && len(flyteWorkflow.Status.History) > 0
+}
+
+// rolledBack returns true when the current generation of the FlyteRegistration, with the given spec hash and workflow
+// version, failed and was rolled back
+func rolledBack(flyteWorkflow *v1.FlyteRegistration, hash string, workflowVersion string) bool {
+	rollback := flyteWorkflow.Status.Rollback
+	return rollback != nil && flyteWorkflow.Status.ObservedGeneration == flyteWorkflow.Generation &&
+		rollback.FailedSpecHash == hash && rollback.FailedVersion == workflowVersion
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/metrics"
+)
+
+// defaultSmokeTestTimeout is how long the execution of a smoke test may run when the spec does not set a timeout
+const defaultSmokeTestTimeout = 30 * time.Minute
+
+// startSmokeTest launches the execution of the smoke test of a version that has just been registered into a target,
+// and requeues the FlyteRegistration to poll it. The FlyteRegistration is not Ready until the execution has succeeded.
+// The launch plans that were active before the registration are kept on the status to roll them back to.
+func (r *FlyteRegistrationReconciler) startSmokeTest(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, t target, previous []v1.LaunchPlanStatus, flyteAuth flyte.Auth) (ctrl.Result, error) {
+	smokeTest := flyteWorkflow.Spec.SmokeTest
+	flyteWorkflow.Status.SmokeTest = nil
+
+	inputs, err := smokeTestInputs(smokeTest)
+	if err != nil {
+		return r.failReconcile(ctx, flyteWorkflow, v1.ConditionTypeSmokeTested, v1.ReasonSmokeTestLaunchFailed, err)
+	}
+
+	name, err := r.FlyteAdminClient.CreateExecution(ctx, flyte.ExecutionRequest{
+		LaunchPlan: smokeTest.LaunchPlan,
+		Meta:       flyte.WorkflowMetadata{WorkflowVersion: t.version, Domain: t.domain, Project: t.project},
+		Inputs:     inputs,
+	}, flyteAuth)
+	if err != nil {
+		return r.failReconcile(ctx, flyteWorkflow, v1.ConditionTypeSmokeTested, v1.ReasonSmokeTestLaunchFailed,
+			fmt.Errorf("failed to launch smoke test: %w", err))
+	}
+
+	flyteWorkflow.Status.SmokeTest = &v1.SmokeTestStatus{
+		Project:             t.project,
+		Domain:              t.domain,
+		ExecutionID:         name,
+		WorkflowVersion:     t.version,
+		Outcome:             v1.SmokeTestOutcomeRunning,
+		StartTime:           metav1.Now(),
+		PreviousLaunchPlans: previous,
+	}
+
+	message := fmt.Sprintf("launched execution %s of launch plan %s version %s in %s/%s", name, smokeTest.LaunchPlan, t.version, t.project, t.domain)
+	setCondition(flyteWorkflow, v1.ConditionTypeSmokeTested, metav1.ConditionUnknown, v1.ReasonSmokeTestRunning, message)
+	setCondition(flyteWorkflow, v1.ConditionTypeReady, metav1.ConditionFalse, v1.ReasonSmokeTestRunning, message)
+	r.Recorder.Event(flyteWorkflow, corev1.EventTypeNormal, EventReasonSmokeTestStarted, message)
+
+	if err := r.K8sClient.Status().Update(ctx, flyteWorkflow); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+	}
+	metrics.SetFailed(client.ObjectKeyFromObject(flyteWorkflow), false)
+
+	return ctrl.Result{RequeueAfter: r.Config.SmokeTestPollInterval}, nil
+}
+
+// reconcileSmokeTest polls the execution of the running smoke test of the registered version, and requeues the
+// FlyteRegistration until it completes or times out. An execution that times out is terminated.
+func (r *FlyteRegistrationReconciler) reconcileSmokeTest(ctx context.Context, flyteWorkflow *v1.FlyteRegistration) (ctrl.Result, error) {
+	smokeTest := flyteWorkflow.Status.SmokeTest
+
+	flyteAuth, err := r.flyteAuth(ctx, flyteWorkflow)
+	if err != nil {
+		return r.failReconcile(ctx, flyteWorkflow, v1.ConditionTypeSmokeTested, v1.ReasonCredentialsUnavailable, err)
+	}
+
+	// The rollback of a failed smoke test is retried until it succeeds
+	if smokeTest.Outcome != v1.SmokeTestOutcomeRunning {
+		return r.failSmokeTest(ctx, flyteWorkflow, flyteAuth)
+	}
+
+	execution, err := r.FlyteAdminClient.GetExecution(ctx, smokeTest.Project, smokeTest.Domain, smokeTest.ExecutionID, flyteAuth)
+	if err != nil {
+		return r.failReconcile(ctx, flyteWorkflow, v1.ConditionTypeSmokeTested, v1.ReasonSmokeTestLaunchFailed,
+			fmt.Errorf("failed to poll smoke test: %w", err))
+	}
+
+	phaseChanged := smokeTest.Phase != execution.Phase
+	smokeTest.Phase = execution.Phase
+	launchPlan := flyteWorkflow.Spec.SmokeTest.LaunchPlan
+
+	if !execution.Completed() {
+		timeout := defaultSmokeTestTimeout
+		if flyteWorkflow.Spec.SmokeTest.Timeout != nil {
+			timeout = flyteWorkflow.Spec.SmokeTest.Timeout.Duration
+		}
+
+		remaining := timeout - time.Since(smokeTest.StartTime.Time)
+		if remaining > 0 {
+			if phaseChanged {
+				if err := r.K8sClient.Status().Update(ctx, flyteWorkflow); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+				}
+			}
+			return ctrl.Result{RequeueAfter: min(r.Config.SmokeTestPollInterval, remaining)}, nil
+		}
+
+		if err := r.FlyteAdminClient.TerminateExecution(ctx, smokeTest.Project, smokeTest.Domain, smokeTest.ExecutionID, flyteAuth); err != nil {
+			return r.failReconcile(ctx, flyteWorkflow, v1.ConditionTypeSmokeTested, v1.ReasonSmokeTestLaunchFailed,
+				fmt.Errorf("failed to terminate smoke test: %w", err))
+		}
+
+		now := metav1.Now()
+		smokeTest.Outcome = v1.SmokeTestOutcomeTimedOut
+		smokeTest.CompletionTime = &now
+		smokeTest.Message = fmt.Sprintf("execution %s of launch plan %s version %s did not complete within %s",
+			smokeTest.ExecutionID, launchPlan, smokeTest.WorkflowVersion, timeout)
+		return r.failSmokeTest(ctx, flyteWorkflow, flyteAuth)
+	}
+
+	now := metav1.Now()
+	smokeTest.CompletionTime = &now
+
+	if execution.Phase != flyte.ExecutionPhaseSucceeded {
+		smokeTest.Outcome = v1.SmokeTestOutcomeFailed
+		smokeTest.Message = fmt.Sprintf("execution %s of launch plan %s version %s ended in phase %s",
+			smokeTest.ExecutionID, launchPlan, smokeTest.WorkflowVersion, execution.Phase)
+		if execution.Error != "" {
+			smokeTest.Message += ": " + execution.Error
+		}
+		return r.failSmokeTest(ctx, flyteWorkflow, flyteAuth)
+	}
+
+	smokeTest.Outcome = v1.SmokeTestOutcomeSucceeded
+	smokeTest.Message = ""
+	recordReadyVersion(flyteWorkflow, v1.RegisteredVersion{
+		WorkflowVersion: flyteWorkflow.Status.WorkflowVersion,
+		Digest:          flyteWorkflow.Status.ResolvedDigest,
+		RegisteredTime:  now,
+		LaunchPlans:     flyteWorkflow.Status.LaunchPlans,
+	})
+	message := fmt.Sprintf("execution %s of launch plan %s version %s succeeded", smokeTest.ExecutionID, launchPlan, smokeTest.WorkflowVersion)
+	setCondition(flyteWorkflow, v1.ConditionTypeSmokeTested, metav1.ConditionTrue, v1.ReasonSmokeTestSucceeded, message)
+	setCondition(flyteWorkflow, v1.ConditionTypeReady, metav1.ConditionTrue, v1.ReasonSmokeTestSucceeded, message)
+	flyteWorkflow.Status.LastError = ""
+	r.Recorder.Event(flyteWorkflow, corev1.EventTypeNormal, EventReasonSmokeTestSucceeded, message)
+
+	if err := r.K8sClient.Status().Update(ctx, flyteWorkflow); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+	}
+	metrics.SetFailed(client.ObjectKeyFromObject(flyteWorkflow), false)
+
+	log.FromContext(ctx).Info("smoke test succeeded", "name", flyteWorkflow.Name, "execution", smokeTest.ExecutionID)
+	return r.result(flyteWorkflow), nil
+}
+
+// failSmokeTest records a failed smoke test on the status of the FlyteRegistration and in a Warning Event, rolling
+// back to the last version registered successfully, or restoring the launch plans that were active before the
+// registration, when the spec asks for it. The registration is not retried, it stays failed until the spec changes or
+// another reconciliation is requested.
+func (r *FlyteRegistrationReconciler) failSmokeTest(ctx context.Context, flyteWorkflow *v1.FlyteRegistration, flyteAuth flyte.Auth) (ctrl.Result, error) {
+	smokeTest := flyteWorkflow.Status.SmokeTest
+
+	if !smokeTest.RolledBack && (canRollBack(flyteWorkflow) || flyteWorkflow.Spec.SmokeTest.RollbackLaunchPlans) {
+		var err error
+		if canRollBack(flyteWorkflow) {
+			err = r.rollBack(ctx, flyteWorkflow, smokeTest.WorkflowVersion, flyteWorkflow.Status.LastRegisteredSpecHash, smokeTest.Message, flyteAuth)
+		} else if err = r.restoreLaunchPlans(ctx, flyteWorkflow, smokeTest.PreviousLaunchPlans, flyteAuth); err == nil {
+			r.Recorder.Eventf(flyteWorkflow, corev1.EventTypeNormal, EventReasonLaunchPlansRolledBack,
+				"Restored the launch plans to the versions active before version %s was registered", smokeTest.WorkflowVersion)
+		}
+		if err != nil {
+			return r.failReconcile(ctx, flyteWorkflow, v1.ConditionTypeSmokeTested, v1.ReasonLaunchPlanUpdateFailed,
+				fmt.Errorf("%s, failed to roll back launch plans: %w", smokeTest.Message, err))
+		}
+		smokeTest.RolledBack = true
+	}
+
+	if err := r.recordFailure(ctx, flyteWorkflow, v1.ConditionTypeSmokeTested, v1.ReasonSmokeTestFailed, errors.New(smokeTest.Message)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	log.FromContext(ctx).Info("smoke test failed", "name", flyteWorkflow.Name, "execution", smokeTest.ExecutionID, "outcome", smokeTest.Outcome)
+	return r.result(flyteWorkflow), nil
+}
+
+// smokeTestInputs decodes the inputs of the execution of a smoke test
+func smokeTestInputs(smokeTest *v1.SmokeTestSpec) (map[string]any, error) {
+	if smokeTest.Inputs == nil || len(smokeTest.Inputs.Raw) == 0 {
+		return nil, nil
+	}
+
+	var inputs map[string]any
+	if err := json.Unmarshal(smokeTest.Inputs.Raw, &inputs); err != nil {
+		return nil, fmt.Errorf("failed to decode smoke test inputs: %w", err)
+	}
+
+	return inputs, nil
+}
+
+// smokeTestPending returns true when the smoke test of the registered version is still running, or has failed and
+// has not been rolled back yet
+func smokeTestPending(flyteWorkflow *v1.FlyteRegistration) bool {
+	smokeTest := flyteWorkflow.Status.SmokeTest
+	if smokeTest == nil || flyteWorkflow.Spec.SmokeTest == nil {
+		return false
+	}
+
+	switch smokeTest.Outcome {
+	case v1.SmokeTestOutcomeRunning:
+		return true
+	case v1.SmokeTestOutcomeFailed, v1.SmokeTestOutcomeTimedOut:
+		return (flyteWorkflow.Spec.SmokeTest.RollbackLaunchPlans || canRollBack(flyteWorkflow)) && !smokeTest.RolledBack
+	}
+
+	return false
+}
+
+// smokeTested returns true when the version registered into the first target has a smoke test, whatever its outcome.
+// The registration of such a version succeeded, it is not Ready until the smoke test succeeds.
+func smokeTested(flyteWorkflow *v1.FlyteRegistration, workflowVersion string) bool {
+	smokeTest := flyteWorkflow.Status.SmokeTest
+	if smokeTest == nil || flyteWorkflow.Spec.SmokeTest == nil {
+		return false
+	}
+
+	t := registrationTargets(flyteWorkflow, workflowVersion)[0]
+	return smokeTest.Project == t.project && smokeTest.Domain == t.domain && smokeTest.WorkflowVersion == t.version &&
+		apimeta.IsStatusConditionTrue(flyteWorkflow.Status.Conditions, v1.ConditionTypeRegistered)
+}
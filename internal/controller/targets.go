@@ -0,0 +1,155 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/digest"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/flyte"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/signature"
+)
+
+// target is a flyte project and domain the workflow package is registered into, with the version registered there
+type target struct {
+	project string
+	domain  string
+	version string
+}
+
+// targetError is the error a target failed with, and the condition and reason it is reported with
+type targetError struct {
+	conditionType string
+	reason        string
+	err           error
+}
+
+// deterministic returns true when the target failed in a way that retrying the same version fails again: flyte admin
+// rejected the package, or the package does not match its digest or signature. Outages, timeouts and throttling are
+// transient, they are retried.
+func (e *targetError) deterministic() bool {
+	return errors.Is(e.err, flyte.ErrRegistrationRejected) || errors.Is(e.err, digest.ErrMismatch) ||
+		errors.Is(e.err, signature.ErrUnverified)
+}
+
+// targetOutcome is the outcome of registering the workflow package into a target
+type targetOutcome struct {
+	target   target
+	artifact internal.Artifact
+	results  []flyte.RegistrationResult
+	// launchPlans is the state of the launch plans of the spec in the target
+	launchPlans []v1.LaunchPlanStatus
+	message     string
+	err         *targetError
+}
+
+// registrationTargets returns the targets a FlyteRegistration registers the workflow package into, the project and
+// domain of the spec when it does not list any. Targets without a version of their own use the given version.
+func registrationTargets(flyteWorkflow *v1.FlyteRegistration, workflowVersion string) []target {
+	spec := flyteWorkflow.Spec
+	if len(spec.Targets) == 0 {
+		return []target{{project: spec.WorkflowProject, domain: spec.WorkflowDomain, version: workflowVersion}}
+	}
+
+	targets := make([]target, 0, len(spec.Targets))
+	for _, t := range spec.Targets {
+		registered := target{project: t.Project, domain: t.Domain, version: t.WorkflowVersion}
+		if registered.project == "" {
+			registered.project = spec.WorkflowProject
+		}
+		if registered.version == "" {
+			registered.version = workflowVersion
+		}
+		targets = append(targets, registered)
+	}
+
+	return targets
+}
+
+// groupByVersion groups the targets by the version registered into them, in the order the versions first appear
+func groupByVersion(targets []target) [][]target {
+	var groups [][]target
+	index := make(map[string]int)
+
+	for _, t := range targets {
+		i, ok := index[t.version]
+		if !ok {
+			i = len(groups)
+			index[t.version] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], t)
+	}
+
+	return groups
+}
+
+// previousTargetStatus returns the status of the latest registration into a target. FlyteRegistrations without
+// targets that were registered before the targets were tracked only hold it on the top level of their status.
+func previousTargetStatus(flyteWorkflow *v1.FlyteRegistration, t target) v1.TargetStatus {
+	for _, status := range flyteWorkflow.Status.Targets {
+		if status.Project == t.project && status.Domain == t.domain {
+			return status
+		}
+	}
+
+	status := v1.TargetStatus{Project: t.project, Domain: t.domain}
+	if len(flyteWorkflow.Spec.Targets) == 0 && len(flyteWorkflow.Status.Targets) == 0 {
+		status.Ready = apimeta.IsStatusConditionTrue(flyteWorkflow.Status.Conditions, v1.ConditionTypeReady)
+		status.WorkflowVersion = flyteWorkflow.Status.WorkflowVersion
+		status.ResolvedDigest = flyteWorkflow.Status.ResolvedDigest
+		status.LastSuccessTime = flyteWorkflow.Status.LastSuccessTime
+	}
+
+	return status
+}
+
+// registeredTargets returns the projects, domains and versions the workflow package was last registered into
+// successfully, from the top level of the status for FlyteRegistrations registered before the targets were tracked
+func registeredTargets(flyteWorkflow *v1.FlyteRegistration) []flyte.WorkflowMetadata {
+	status := flyteWorkflow.Status
+	if len(status.Targets) == 0 {
+		if status.WorkflowVersion == "" {
+			return nil
+		}
+
+		return []flyte.WorkflowMetadata{{
+			WorkflowVersion: status.WorkflowVersion,
+			Domain:          status.WorkflowDomain,
+			Project:         status.WorkflowProject,
+		}}
+	}
+
+	var registered []flyte.WorkflowMetadata
+	for _, t := range status.Targets {
+		if t.WorkflowVersion == "" {
+			continue
+		}
+
+		registered = append(registered, flyte.WorkflowMetadata{
+			WorkflowVersion: t.WorkflowVersion,
+			Domain:          t.Domain,
+			Project:         t.Project,
+		})
+	}
+
+	return registered
+}
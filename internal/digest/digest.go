@@ -0,0 +1,59 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package digest holds the logic for resolving the content digests of downloaded workflow packages and verifying them
+// against a pinned digest. Digests are written as `sha256:<hex>`, the form used by OCI registries.
+package digest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// ErrMismatch is returned when none of the resolved digests of a workflow package match the pinned digest
+var ErrMismatch = errors.New("digest mismatch")
+
+// File returns the sha256 digest of the content of a file
+func File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", fmt.Errorf("failed to hash file: %w", err)
+	}
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Verify returns ErrMismatch when a digest is pinned and it is not one of the resolved digests of the package. A
+// package may resolve to several digests, for example the digest of an OCI manifest and of its layer.
+func Verify(pinned string, resolved ...string) error {
+	if pinned == "" || slices.Contains(resolved, pinned) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: pinned %s, resolved %s", ErrMismatch, pinned, strings.Join(resolved, ", "))
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package digest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "package.tgz")
+	require.NoError(t, os.WriteFile(path, []byte("package"), 0o600))
+
+	d, err := File(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256:bc4a71180870f7945155fbb02f4b0a2e3faa2a62d6d31b7039013055ed19869a", d)
+}
+
+func TestVerify(t *testing.T) {
+	manifest := "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	layer := "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+
+	assert.NoError(t, Verify("", manifest))
+	assert.NoError(t, Verify(manifest, manifest, layer))
+	assert.NoError(t, Verify(layer, manifest, layer))
+	assert.ErrorIs(t, Verify("sha256:3333333333333333333333333333333333333333333333333333333333333333", manifest, layer), ErrMismatch)
+}
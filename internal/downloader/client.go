@@ -18,44 +18,241 @@ limitations under the License.
 Package downloader handles the downloading of a workflow artifacts from a download source
 
 The idea is that we can easily define what downloader strategy to use and new download strategies
-can be swapped. For now workflow packages will be stored in an OCI registry, but they could as easily be stored in jFrog.
+can be swapped. Workflow packages can be stored in an OCI registry, in jFrog, in S3 or on an HTTPS server, and one
+cluster can use several sources.
 
-We use a strategy pattern, e.g a `config.DownloadStrategy` is defined as an enum e.g `jfrog` or `oci`
-which instantiates a given specific strategy and returns it from the NewDownloader function.
+We use a strategy pattern, each strategy e.g `jfrog`, `oci`, `s3` or `https` is registered in a Registry under the scheme of the
+package URIs it downloads, e.g `oci://adarga/example-workflow`. The Registry routes each download to the strategy
+registered for the scheme of the package URI, and URIs without a scheme to the `config.DownloadStrategy` default.
 Each strategy should implement the DownloadArtifact function which downloaders the artifact from
-its source.
+its source into the workspace of the request, and returns the digest the artifact resolved to. When credentials are
+given they are used instead of the credentials in the config, and when a digest is pinned the artifact is verified
+against it. The strategies also implement ListVersions, which lists the published versions of a package for version
+policies, or returns internal.ErrVersionsUnsupported when the source cannot list them.
 */
 package downloader
 
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/https"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/jfrog"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/oci"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/ratelimit"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/s3"
 )
 
-// Client is an interface for downloading artifacts
+// Client is an interface for downloading artifacts and listing their versions
 //
 //go:generate mockery --name=Client
 type Client interface {
-	DownloadArtifact(ctx context.Context, uri string, version string) (string, error)
+	DownloadArtifact(ctx context.Context, req internal.DownloadRequest) (internal.Artifact, error)
+	ListVersions(ctx context.Context, req internal.VersionsRequest) ([]string, error)
 }
 
-// NewClient returns the relevant downloader based on the given download strategy
+// Registry is a Client that routes each download to the strategy registered for the scheme of the package URI
+type Registry struct {
+	defaultStrategy string
+	strategies      map[string]Client
+}
+
+// NewRegistry returns an empty registry, URIs without a scheme are downloaded with the default strategy
+func NewRegistry(defaultStrategy string) *Registry {
+	return &Registry{
+		defaultStrategy: defaultStrategy,
+		strategies:      map[string]Client{},
+	}
+}
+
+// Register registers the strategy used to download the package URIs with the given scheme
+func (r *Registry) Register(scheme string, strategy Client) {
+	r.strategies[scheme] = strategy
+}
+
+// DownloadArtifact downloads an artifact with the strategy registered for the scheme of the uri. The strategy is
+// given the uri without its scheme.
+func (r *Registry) DownloadArtifact(ctx context.Context, req internal.DownloadRequest) (internal.Artifact, error) {
+	strategy, path, err := r.route(req.URI)
+	if err != nil {
+		return internal.Artifact{}, err
+	}
+
+	req.URI = path
+	return strategy.DownloadArtifact(ctx, req)
+}
+
+// ListVersions lists the versions of an artifact with the strategy registered for the scheme of the uri. The strategy
+// is given the uri without its scheme.
+func (r *Registry) ListVersions(ctx context.Context, req internal.VersionsRequest) ([]string, error) {
+	strategy, path, err := r.route(req.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	req.URI = path
+	return strategy.ListVersions(ctx, req)
+}
+
+// route returns the strategy registered for the scheme of the uri, and the uri without its scheme
+func (r *Registry) route(uri string) (Client, string, error) {
+	scheme, path := ParseURI(uri)
+	if scheme == "" {
+		scheme = r.defaultStrategy
+	}
+
+	strategy, ok := r.strategies[scheme]
+	if !ok {
+		return nil, "", fmt.Errorf("no downloader registered for scheme %s", scheme)
+	}
+
+	return strategy, path, nil
+}
+
+// RateLimited is a strategy that waits for the token bucket of the host it downloads from before each download. When
+// the Host is empty the host is read from the package URI of each download.
+type RateLimited struct {
+	Client
+	Host    string
+	Limiter *ratelimit.Limiter
+}
+
+// DownloadArtifact downloads an artifact once the rate limit of the host allows it
+func (r *RateLimited) DownloadArtifact(ctx context.Context, req internal.DownloadRequest) (internal.Artifact, error) {
+	if err := r.Limiter.Wait(ctx, r.host(req.URI)); err != nil {
+		return internal.Artifact{}, err
+	}
+
+	return r.Client.DownloadArtifact(ctx, req)
+}
+
+// ListVersions lists the versions of an artifact once the rate limit of the host allows it
+func (r *RateLimited) ListVersions(ctx context.Context, req internal.VersionsRequest) ([]string, error) {
+	if err := r.Limiter.Wait(ctx, r.host(req.URI)); err != nil {
+		return nil, err
+	}
+
+	return r.Client.ListVersions(ctx, req)
+}
+
+// host returns the host rate limited for a package URI
+func (r *RateLimited) host(uri string) string {
+	if r.Host == "" {
+		return ratelimit.Host(uri)
+	}
+
+	return r.Host
+}
+
+// ParseURI splits a package URI into its scheme and the rest of the URI. The scheme is empty for bare URIs.
+func ParseURI(uri string) (string, string) {
+	scheme, path, ok := strings.Cut(uri, "://")
+	if !ok {
+		return "", uri
+	}
+
+	return scheme, path
+}
+
+// PackageURI returns the package URI with the scheme of the source type, when the URI has no scheme of its own
+func PackageURI(uri string, sourceType string) string {
+	if scheme, _ := ParseURI(uri); scheme != "" || sourceType == "" {
+		return uri
+	}
+
+	return sourceType + "://" + uri
+}
+
+// Strategy returns the download strategy of a package URI, as it is routed by a registry: the scheme of the URI, or
+// the source type for a URI without a scheme, or the default strategy when neither is set
+func Strategy(uri string, sourceType string, defaultStrategy string) string {
+	scheme, _ := ParseURI(PackageURI(uri, sourceType))
+	if scheme == "" {
+		return defaultStrategy
+	}
+
+	return scheme
+}
+
+// Schemes returns the schemes of the package URIs that can be downloaded with the configured download strategies
+func Schemes(cfg internal.Config) []string {
+	schemes := []string{internal.DownloadStrategyOCI, internal.DownloadStrategyHTTPS}
+	if cfg.DownloadStrategy == internal.DownloadStrategyJFrog || cfg.JfrogURL != "" {
+		schemes = append(schemes, internal.DownloadStrategyJFrog)
+	}
+	if cfg.DownloadStrategy == internal.DownloadStrategyS3 || cfg.S3Region != "" {
+		schemes = append(schemes, internal.DownloadStrategyS3)
+	}
+
+	return schemes
+}
+
+// NewClient returns a registry with the download strategies that are configured. The OCI and HTTPS strategies are
+// always registered, the JFrog strategy is also registered when an artifactory URL is configured and the S3 strategy
+// when an S3 region is configured. The downloads from each registry host are rate limited.
 func NewClient(ctx context.Context, cfg internal.Config) (Client, error) {
-	switch cfg.DownloadStrategy {
-	case internal.DownloadStrategyOCI:
-		return oci.NewDownloader(cfg)
-	case internal.DownloadStrategyJFrog:
+	if !slices.Contains(internal.DownloadStrategies, cfg.DownloadStrategy) {
+		return nil, errors.New("invalid downloader strategy")
+	}
+
+	registry := NewRegistry(cfg.DownloadStrategy)
+	limiter := ratelimit.NewLimiter(cfg.RegistryRateLimit, cfg.RegistryRateBurst)
+
+	ociDownloader, err := oci.NewDownloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+	registry.Register(internal.DownloadStrategyOCI, &RateLimited{
+		Client:  ociDownloader,
+		Host:    ratelimit.Host(cfg.OCIRegistry),
+		Limiter: limiter,
+	})
+
+	if slices.Contains(Schemes(cfg), internal.DownloadStrategyJFrog) {
 		d := jfrog.Downloader{Config: cfg}
 		if err := d.SetupDownloader(ctx); err != nil {
 			return nil, errors.New("failed to setup JFrog downloader")
 		}
+		registry.Register(internal.DownloadStrategyJFrog, &RateLimited{
+			Client:  &d,
+			Host:    ratelimit.Host(cfg.JfrogURL),
+			Limiter: limiter,
+		})
+	}
 
-		return &d, nil
-	default:
-		return nil, errors.New("invalid downloader strategy")
+	if slices.Contains(Schemes(cfg), internal.DownloadStrategyS3) {
+		d, err := s3.NewDownloader(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		registry.Register(internal.DownloadStrategyS3, &RateLimited{
+			Client:  d,
+			Host:    s3Host(cfg),
+			Limiter: limiter,
+		})
+	}
+
+	httpsDownloader, err := https.NewDownloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+	registry.Register(internal.DownloadStrategyHTTPS, &RateLimited{
+		Client:  httpsDownloader,
+		Limiter: limiter,
+	})
+
+	return registry, nil
+}
+
+// s3Host returns the host the S3 strategy downloads from, for rate limiting
+func s3Host(cfg internal.Config) string {
+	if cfg.S3Endpoint != "" {
+		return ratelimit.Host(cfg.S3Endpoint)
 	}
+
+	return fmt.Sprintf("s3.%s.amazonaws.com", cfg.S3Region)
 }
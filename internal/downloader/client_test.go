@@ -19,9 +19,13 @@ package downloader
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/downloader/mocks"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/ratelimit"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewClient(t *testing.T) {
@@ -41,3 +45,123 @@ func TestNewClient(t *testing.T) {
 		assert.ErrorContains(t, err, "invalid downloader strategy")
 	})
 }
+
+func TestRegistryDownloadArtifact(t *testing.T) {
+	t.Run("scheme prefixed uri is routed to the registered strategy", func(t *testing.T) {
+		ociDownloader := mocks.NewClient(t)
+		jfrogDownloader := mocks.NewClient(t)
+		jfrogDownloader.EXPECT().DownloadArtifact(mock.Anything, internal.DownloadRequest{URI: "repo/example-workflow", Version: "1.0.0"}).Return(internal.Artifact{Path: "test-path"}, nil).Once()
+
+		registry := NewRegistry("oci")
+		registry.Register("oci", ociDownloader)
+		registry.Register("jfrog", jfrogDownloader)
+
+		artifact, err := registry.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "jfrog://repo/example-workflow", Version: "1.0.0"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test-path", artifact.Path)
+	})
+
+	t.Run("bare uri is routed to the default strategy", func(t *testing.T) {
+		ociDownloader := mocks.NewClient(t)
+		ociDownloader.EXPECT().DownloadArtifact(mock.Anything, internal.DownloadRequest{URI: "adarga/example-workflow", Version: "1.0.0"}).Return(internal.Artifact{Path: "test-path"}, nil).Once()
+
+		registry := NewRegistry("oci")
+		registry.Register("oci", ociDownloader)
+
+		artifact, err := registry.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "adarga/example-workflow", Version: "1.0.0"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test-path", artifact.Path)
+	})
+
+	t.Run("failure case: unregistered scheme", func(t *testing.T) {
+		registry := NewRegistry("oci")
+
+		_, err := registry.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "ftp://example.com/example-workflow", Version: "1.0.0"})
+
+		assert.ErrorContains(t, err, "no downloader registered for scheme ftp")
+	})
+}
+
+func TestRegistryListVersions(t *testing.T) {
+	t.Run("scheme prefixed uri is routed to the registered strategy", func(t *testing.T) {
+		jfrogDownloader := mocks.NewClient(t)
+		jfrogDownloader.EXPECT().ListVersions(mock.Anything, internal.VersionsRequest{URI: "repo/example-workflow"}).Return([]string{"1.0.0"}, nil).Once()
+
+		registry := NewRegistry("oci")
+		registry.Register("oci", mocks.NewClient(t))
+		registry.Register("jfrog", jfrogDownloader)
+
+		versions, err := registry.ListVersions(context.Background(), internal.VersionsRequest{URI: "jfrog://repo/example-workflow"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1.0.0"}, versions)
+	})
+
+	t.Run("failure case: unregistered scheme", func(t *testing.T) {
+		registry := NewRegistry("oci")
+
+		_, err := registry.ListVersions(context.Background(), internal.VersionsRequest{URI: "ftp://example.com/example-workflow"})
+
+		assert.ErrorContains(t, err, "no downloader registered for scheme ftp")
+	})
+}
+
+func TestPackageURI(t *testing.T) {
+	assert.Equal(t, "jfrog://repo/example-workflow", PackageURI("repo/example-workflow", "jfrog"))
+	assert.Equal(t, "oci://adarga/example-workflow", PackageURI("oci://adarga/example-workflow", "jfrog"))
+	assert.Equal(t, "adarga/example-workflow", PackageURI("adarga/example-workflow", ""))
+}
+
+func TestStrategy(t *testing.T) {
+	assert.Equal(t, "oci", Strategy("oci://adarga/example-workflow", "jfrog", "s3"))
+	assert.Equal(t, "jfrog", Strategy("repo/example-workflow", "jfrog", "s3"))
+	assert.Equal(t, "s3", Strategy("bucket/example-workflow.tgz", "", "s3"))
+}
+
+func TestSchemes(t *testing.T) {
+	assert.Equal(t, []string{"oci", "https"}, Schemes(internal.Config{DownloadStrategy: "oci"}))
+	assert.Equal(t, []string{"oci", "https", "jfrog"}, Schemes(internal.Config{DownloadStrategy: "oci", JfrogURL: "url"}))
+	assert.Equal(t, []string{"oci", "https", "s3"}, Schemes(internal.Config{DownloadStrategy: "s3"}))
+	assert.Equal(t, []string{"oci", "https", "s3"}, Schemes(internal.Config{DownloadStrategy: "oci", S3Region: "eu-west-1"}))
+}
+
+func TestRateLimitedDownloadArtifact(t *testing.T) {
+	t.Run("downloads within the rate limit", func(t *testing.T) {
+		ociDownloader := mocks.NewClient(t)
+		ociDownloader.EXPECT().DownloadArtifact(mock.Anything, internal.DownloadRequest{URI: "adarga/example-workflow", Version: "1.0.0"}).Return(internal.Artifact{Path: "test-path"}, nil).Once()
+
+		limited := &RateLimited{Client: ociDownloader, Host: "registry.example.com", Limiter: ratelimit.NewLimiter(1, 1)}
+
+		artifact, err := limited.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "adarga/example-workflow", Version: "1.0.0"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test-path", artifact.Path)
+	})
+
+	t.Run("failure case: context done while waiting for the rate limit", func(t *testing.T) {
+		limiter := ratelimit.NewLimiter(0.001, 1)
+		assert.NoError(t, limiter.Wait(context.Background(), "registry.example.com"))
+
+		limited := &RateLimited{Client: mocks.NewClient(t), Host: "registry.example.com", Limiter: limiter}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := limited.DownloadArtifact(ctx, internal.DownloadRequest{URI: "adarga/example-workflow", Version: "1.0.0"})
+
+		assert.ErrorContains(t, err, "waiting for the rate limit of registry.example.com")
+	})
+	t.Run("failure case: the host is read from the package uri when it is not set", func(t *testing.T) {
+		limiter := ratelimit.NewLimiter(0.001, 1)
+		assert.NoError(t, limiter.Wait(context.Background(), "downloads.example.com"))
+
+		limited := &RateLimited{Client: mocks.NewClient(t), Limiter: limiter}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := limited.DownloadArtifact(ctx, internal.DownloadRequest{URI: "downloads.example.com/example-workflow", Version: "1.0.0"})
+
+		assert.ErrorContains(t, err, "waiting for the rate limit of downloads.example.com")
+	})
+}
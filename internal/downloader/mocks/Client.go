@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	internal "github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -21,27 +23,27 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
-// DownloadArtifact provides a mock function with given fields: ctx, uri, version
-func (_m *Client) DownloadArtifact(ctx context.Context, uri string, version string) (string, error) {
-	ret := _m.Called(ctx, uri, version)
+// DownloadArtifact provides a mock function with given fields: ctx, req
+func (_m *Client) DownloadArtifact(ctx context.Context, req internal.DownloadRequest) (internal.Artifact, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DownloadArtifact")
 	}
 
-	var r0 string
+	var r0 internal.Artifact
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, uri, version)
+	if rf, ok := ret.Get(0).(func(context.Context, internal.DownloadRequest) (internal.Artifact, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, uri, version)
+	if rf, ok := ret.Get(0).(func(context.Context, internal.DownloadRequest) internal.Artifact); ok {
+		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(internal.Artifact)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, uri, version)
+	if rf, ok := ret.Get(1).(func(context.Context, internal.DownloadRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,25 +58,83 @@ type Client_DownloadArtifact_Call struct {
 
 // DownloadArtifact is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uri string
-//   - version string
-func (_e *Client_Expecter) DownloadArtifact(ctx interface{}, uri interface{}, version interface{}) *Client_DownloadArtifact_Call {
-	return &Client_DownloadArtifact_Call{Call: _e.mock.On("DownloadArtifact", ctx, uri, version)}
+//   - req internal.DownloadRequest
+func (_e *Client_Expecter) DownloadArtifact(ctx interface{}, req interface{}) *Client_DownloadArtifact_Call {
+	return &Client_DownloadArtifact_Call{Call: _e.mock.On("DownloadArtifact", ctx, req)}
+}
+
+func (_c *Client_DownloadArtifact_Call) Run(run func(ctx context.Context, req internal.DownloadRequest)) *Client_DownloadArtifact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(internal.DownloadRequest))
+	})
+	return _c
+}
+
+func (_c *Client_DownloadArtifact_Call) Return(_a0 internal.Artifact, _a1 error) *Client_DownloadArtifact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_DownloadArtifact_Call) RunAndReturn(run func(context.Context, internal.DownloadRequest) (internal.Artifact, error)) *Client_DownloadArtifact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVersions provides a mock function with given fields: ctx, req
+func (_m *Client) ListVersions(ctx context.Context, req internal.VersionsRequest) ([]string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVersions")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, internal.VersionsRequest) ([]string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, internal.VersionsRequest) []string); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, internal.VersionsRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ListVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVersions'
+type Client_ListVersions_Call struct {
+	*mock.Call
+}
+
+// ListVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req internal.VersionsRequest
+func (_e *Client_Expecter) ListVersions(ctx interface{}, req interface{}) *Client_ListVersions_Call {
+	return &Client_ListVersions_Call{Call: _e.mock.On("ListVersions", ctx, req)}
 }
 
-func (_c *Client_DownloadArtifact_Call) Run(run func(ctx context.Context, uri string, version string)) *Client_DownloadArtifact_Call {
+func (_c *Client_ListVersions_Call) Run(run func(ctx context.Context, req internal.VersionsRequest)) *Client_ListVersions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(internal.VersionsRequest))
 	})
 	return _c
 }
 
-func (_c *Client_DownloadArtifact_Call) Return(_a0 string, _a1 error) *Client_DownloadArtifact_Call {
+func (_c *Client_ListVersions_Call) Return(_a0 []string, _a1 error) *Client_ListVersions_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Client_DownloadArtifact_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *Client_DownloadArtifact_Call {
+func (_c *Client_ListVersions_Call) RunAndReturn(run func(context.Context, internal.VersionsRequest) ([]string, error)) *Client_ListVersions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,300 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flyte
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// MatchableResource is a kind of matchable attributes of a project and domain, named as the flytectl command that
+// manages it
+type MatchableResource string
+
+// Matchable resources managed by the operator
+const (
+	MatchableResourceTaskResource            MatchableResource = "task-resource-attribute"
+	MatchableResourceExecutionQueue          MatchableResource = "execution-queue-attribute"
+	MatchableResourceExecutionClusterLabel   MatchableResource = "execution-cluster-label"
+	MatchableResourceWorkflowExecutionConfig MatchableResource = "workflow-execution-config"
+)
+
+// MatchableResources are the matchable resources managed by the operator, in the order they are applied
+var MatchableResources = []MatchableResource{
+	MatchableResourceTaskResource,
+	MatchableResourceExecutionQueue,
+	MatchableResourceExecutionClusterLabel,
+	MatchableResourceWorkflowExecutionConfig,
+}
+
+// MatchableAttributes are the matchable attributes of a project and domain, the attributes that are not set are nil.
+// The attributes are in the format of the attribute files of flytectl.
+type MatchableAttributes struct {
+	Project                 string
+	Domain                  string
+	TaskResources           *TaskResourceAttributes
+	ExecutionQueue          *ExecutionQueueAttributes
+	ExecutionClusterLabel   *ExecutionClusterLabel
+	WorkflowExecutionConfig *WorkflowExecutionConfig
+}
+
+// TaskResourceAttributes are the default resources requested by tasks and the most they may request
+type TaskResourceAttributes struct {
+	Defaults TaskResourceSpec `json:"defaults,omitempty"`
+	Limits   TaskResourceSpec `json:"limits,omitempty"`
+}
+
+// TaskResourceSpec are the quantities of each resource of a task
+type TaskResourceSpec struct {
+	CPU              string `json:"cpu,omitempty"`
+	GPU              string `json:"gpu,omitempty"`
+	Memory           string `json:"memory,omitempty"`
+	EphemeralStorage string `json:"ephemeral_storage,omitempty"`
+}
+
+// ExecutionQueueAttributes are the tags that select the queue executions are scheduled on
+type ExecutionQueueAttributes struct {
+	Tags []string `json:"tags,omitempty"`
+}
+
+// ExecutionClusterLabel is the label of the cluster executions are scheduled on
+type ExecutionClusterLabel struct {
+	Value string `json:"value,omitempty"`
+}
+
+// WorkflowExecutionConfig is the default configuration of workflow executions
+type WorkflowExecutionConfig struct {
+	MaxParallelism      int32                `json:"max_parallelism,omitempty"`
+	SecurityContext     *SecurityContext     `json:"security_context,omitempty"`
+	RawOutputDataConfig *RawOutputDataConfig `json:"raw_output_data_config,omitempty"`
+	Labels              *KeyValues           `json:"labels,omitempty"`
+	Annotations         *KeyValues           `json:"annotations,omitempty"`
+	Interruptible       *BoolValue           `json:"interruptible,omitempty"`
+	OverwriteCache      bool                 `json:"overwrite_cache,omitempty"`
+}
+
+// SecurityContext is the identity executions run as
+type SecurityContext struct {
+	RunAs *Identity `json:"run_as,omitempty"`
+}
+
+// Identity is the kubernetes service account executions run as
+type Identity struct {
+	K8sServiceAccount string `json:"k8s_service_account,omitempty"`
+}
+
+// RawOutputDataConfig is where executions write their raw output data
+type RawOutputDataConfig struct {
+	OutputLocationPrefix string `json:"output_location_prefix,omitempty"`
+}
+
+// KeyValues are the labels or annotations set on executions
+type KeyValues struct {
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// BoolValue is an optional boolean
+type BoolValue struct {
+	Value bool `json:"value,omitempty"`
+}
+
+// Attributes returns the attributes of a matchable resource, nil when they are not set
+func (m MatchableAttributes) Attributes(resource MatchableResource) any {
+	switch {
+	case resource == MatchableResourceTaskResource && m.TaskResources != nil:
+		return m.TaskResources
+	case resource == MatchableResourceExecutionQueue && m.ExecutionQueue != nil:
+		return m.ExecutionQueue
+	case resource == MatchableResourceExecutionClusterLabel && m.ExecutionClusterLabel != nil:
+		return m.ExecutionClusterLabel
+	case resource == MatchableResourceWorkflowExecutionConfig && m.WorkflowExecutionConfig != nil:
+		return m.WorkflowExecutionConfig
+	}
+
+	return nil
+}
+
+// Resources returns the matchable resources whose attributes are set
+func (m MatchableAttributes) Resources() []MatchableResource {
+	var resources []MatchableResource
+	for _, resource := range MatchableResources {
+		if m.Attributes(resource) != nil {
+			resources = append(resources, resource)
+		}
+	}
+
+	return resources
+}
+
+// Select returns the attributes of the given matchable resources, the attributes of the others are not set
+func (m MatchableAttributes) Select(resources []MatchableResource) MatchableAttributes {
+	selected := MatchableAttributes{Project: m.Project, Domain: m.Domain}
+	for _, resource := range resources {
+		switch resource {
+		case MatchableResourceTaskResource:
+			selected.TaskResources = m.TaskResources
+		case MatchableResourceExecutionQueue:
+			selected.ExecutionQueue = m.ExecutionQueue
+		case MatchableResourceExecutionClusterLabel:
+			selected.ExecutionClusterLabel = m.ExecutionClusterLabel
+		case MatchableResourceWorkflowExecutionConfig:
+			selected.WorkflowExecutionConfig = m.WorkflowExecutionConfig
+		}
+	}
+
+	return selected
+}
+
+// GetMatchableAttributes returns the matchable attributes set on a project and domain
+func (a *AdminClient) GetMatchableAttributes(ctx context.Context, project string, domain string, auth Auth) (MatchableAttributes, error) {
+	attributes := MatchableAttributes{Project: project, Domain: domain}
+
+	for _, resource := range MatchableResources {
+		output, err := a.flytectl(ctx, auth, "get", string(resource), "--project", project, "--domain", domain)
+		if err != nil {
+			if notFound(output) {
+				continue
+			}
+			return MatchableAttributes{}, fmt.Errorf("failed to get %s of %s/%s: %w, output: %s", resource, project, domain, err, output)
+		}
+
+		if err := attributes.decode(resource, output); err != nil {
+			return MatchableAttributes{}, err
+		}
+	}
+
+	return attributes, nil
+}
+
+// UpdateMatchableAttributes replaces the matchable attributes of a project and domain with each of the given
+// attributes that are set, the other matchable resources are left as they are
+func (a *AdminClient) UpdateMatchableAttributes(ctx context.Context, attributes MatchableAttributes, auth Auth) error {
+	for _, resource := range MatchableResources {
+		value := attributes.Attributes(resource)
+		if value == nil {
+			continue
+		}
+
+		if err := a.updateAttributes(ctx, resource, attributes.Project, attributes.Domain, value, auth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteMatchableAttributes deletes the given matchable resources of a project and domain, the resources that are not
+// set are ignored
+func (a *AdminClient) DeleteMatchableAttributes(ctx context.Context, project string, domain string, resources []MatchableResource, auth Auth) error {
+	for _, resource := range resources {
+		output, err := a.flytectl(ctx, auth, "delete", string(resource), "--project", project, "--domain", domain)
+		if err != nil && !notFound(output) {
+			return fmt.Errorf("failed to delete %s of %s/%s: %w, output: %s", resource, project, domain, err, output)
+		}
+	}
+
+	return nil
+}
+
+// updateAttributes replaces a matchable resource of a project and domain using a flytectl attribute file
+func (a *AdminClient) updateAttributes(ctx context.Context, resource MatchableResource, project string, domain string, value any, auth Auth) error {
+	file, err := writeAttributeFile(project, domain, value)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file)
+
+	output, err := a.flytectl(ctx, auth, "update", string(resource), "--attrFile", file)
+	if err != nil {
+		return fmt.Errorf("failed to update %s of %s/%s: %w, output: %s", resource, project, domain, err, output)
+	}
+
+	return nil
+}
+
+// writeAttributeFile writes the attributes of a project and domain to a temporary flytectl attribute file, flytectl
+// reads the file as yaml so it is written as json
+func writeAttributeFile(project string, domain string, value any) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode attributes: %w", err)
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return "", fmt.Errorf("failed to encode attributes: %w", err)
+	}
+	fields["project"] = project
+	fields["domain"] = domain
+
+	encoded, err = json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode attributes: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "attributes-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create attribute file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(encoded); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write attribute file: %w", err)
+	}
+
+	return file.Name(), nil
+}
+
+// decode sets the attributes of a matchable resource from the attribute file printed by flytectl
+func (m *MatchableAttributes) decode(resource MatchableResource, output []byte) error {
+	var err error
+	switch resource {
+	case MatchableResourceTaskResource:
+		m.TaskResources, err = decodeAttributes[TaskResourceAttributes](output)
+	case MatchableResourceExecutionQueue:
+		m.ExecutionQueue, err = decodeAttributes[ExecutionQueueAttributes](output)
+	case MatchableResourceExecutionClusterLabel:
+		m.ExecutionClusterLabel, err = decodeAttributes[ExecutionClusterLabel](output)
+	case MatchableResourceWorkflowExecutionConfig:
+		m.WorkflowExecutionConfig, err = decodeAttributes[WorkflowExecutionConfig](output)
+	}
+
+	return err
+}
+
+// decodeAttributes decodes attributes printed by flytectl as json, empty attributes are returned as nil
+func decodeAttributes[T any](output []byte) (*T, error) {
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return nil, nil
+	}
+
+	var value, empty T
+	if err := json.Unmarshal(output, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode flytectl output: %w", err)
+	}
+	if reflect.DeepEqual(value, empty) {
+		return nil, nil
+	}
+
+	return &value, nil
+}
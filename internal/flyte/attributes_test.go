@@ -0,0 +1,162 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flyte
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/command/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetMatchableAttributes(t *testing.T) {
+	// SHARED INPUTS
+	command := "flytectl"
+
+	flyteAuth := Auth{
+		AdminEndpoint:      "test-endpoint",
+		ClientID:           "test-client-id",
+		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	}
+	authArgs := stringArgs(flyteAuth.args())
+
+	getArgs := func(resource MatchableResource) []interface{} {
+		return append([]interface{}{"get", string(resource), "--project", "test-project", "--domain", "development"}, authArgs...)
+	}
+	notFound := []byte(`Error: rpc error: code = NotFound desc = missing entity of type MATCHABLE_RESOURCE`)
+
+	t.Run("success case", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, getArgs(MatchableResourceTaskResource)...).
+			Return([]byte(`{"project": "test-project", "domain": "development", "defaults": {"cpu": "1", "memory": "1Gi"}, "limits": {"cpu": "2"}}`), nil).Once()
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, getArgs(MatchableResourceExecutionQueue)...).
+			Return(notFound, errors.New("exit status 1")).Once()
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, getArgs(MatchableResourceExecutionClusterLabel)...).
+			Return([]byte(`{"project": "test-project", "domain": "development"}`), nil).Once()
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, getArgs(MatchableResourceWorkflowExecutionConfig)...).
+			Return([]byte(`{"project": "test-project", "domain": "development", "max_parallelism": 5, "interruptible": {"value": true}}`), nil).Once()
+
+		// EXECUTION
+		attributes, err := NewClient(mockCommandExecutor, nil).GetMatchableAttributes(context.Background(), "test-project", "development", flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, MatchableAttributes{
+			Project: "test-project",
+			Domain:  "development",
+			TaskResources: &TaskResourceAttributes{
+				Defaults: TaskResourceSpec{CPU: "1", Memory: "1Gi"},
+				Limits:   TaskResourceSpec{CPU: "2"},
+			},
+			WorkflowExecutionConfig: &WorkflowExecutionConfig{MaxParallelism: 5, Interruptible: &BoolValue{Value: true}},
+		}, attributes)
+	})
+
+	t.Run("failure case", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, getArgs(MatchableResourceTaskResource)...).
+			Return([]byte("connection refused"), errors.New("exit status 1")).Once()
+
+		// EXECUTION
+		_, err := NewClient(mockCommandExecutor, nil).GetMatchableAttributes(context.Background(), "test-project", "development", flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to get task-resource-attribute of test-project/development: exit status 1, output: connection refused")
+	})
+}
+
+func TestManageMatchableAttributes(t *testing.T) {
+	// SHARED INPUTS
+	command := "flytectl"
+
+	flyteAuth := Auth{
+		AdminEndpoint:      "test-endpoint",
+		ClientID:           "test-client-id",
+		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	}
+	authArgs := stringArgs(flyteAuth.args())
+
+	t.Run("update writes an attribute file for each attribute that is set", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		var files []string
+		mockCommandExecutor := mocks.NewExecutor(t)
+		for _, resource := range []MatchableResource{MatchableResourceExecutionQueue, MatchableResourceExecutionClusterLabel} {
+			args := append([]interface{}{"update", string(resource), "--attrFile", mock.AnythingOfType("string")}, authArgs...)
+			mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).
+				Run(func(_ context.Context, _ string, args ...string) {
+					contents, err := os.ReadFile(args[3])
+					assert.NoError(t, err)
+					files = append(files, string(contents))
+				}).Return(nil, nil).Once()
+		}
+
+		// EXECUTION
+		err := NewClient(mockCommandExecutor, nil).UpdateMatchableAttributes(context.Background(), MatchableAttributes{
+			Project:               "test-project",
+			Domain:                "development",
+			ExecutionQueue:        &ExecutionQueueAttributes{Tags: []string{"gpu"}},
+			ExecutionClusterLabel: &ExecutionClusterLabel{Value: "cluster-a"},
+		}, flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			`{"domain":"development","project":"test-project","tags":["gpu"]}`,
+			`{"domain":"development","project":"test-project","value":"cluster-a"}`,
+		}, files)
+	})
+
+	t.Run("delete ignores the attributes that are not set", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		args := append([]interface{}{"delete", "task-resource-attribute", "--project", "test-project", "--domain", "development"}, authArgs...)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(nil, nil).Once()
+		args = append([]interface{}{"delete", "execution-cluster-label", "--project", "test-project", "--domain", "development"}, authArgs...)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).
+			Return([]byte("Error: rpc error: code = NotFound"), errors.New("exit status 1")).Once()
+
+		// EXECUTION
+		err := NewClient(mockCommandExecutor, nil).DeleteMatchableAttributes(context.Background(), "test-project", "development",
+			[]MatchableResource{MatchableResourceTaskResource, MatchableResourceExecutionClusterLabel}, flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: update", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		args := append([]interface{}{"update", "execution-queue-attribute", "--attrFile", mock.AnythingOfType("string")}, authArgs...)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(nil, errors.New("test error")).Once()
+
+		// EXECUTION
+		err := NewClient(mockCommandExecutor, nil).UpdateMatchableAttributes(context.Background(), MatchableAttributes{
+			Project:        "test-project",
+			Domain:         "development",
+			ExecutionQueue: &ExecutionQueueAttributes{Tags: []string{"gpu"}},
+		}, flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to update execution-queue-attribute of test-project/development: test error")
+	})
+}
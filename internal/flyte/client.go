@@ -18,28 +18,66 @@ limitations under the License.
 package flyte
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/command"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/metrics"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/ratelimit"
 )
 
 // Client is an interface for the flyte admin client
 //
 //go:generate mockery --name=Client
 type Client interface {
-	RegisterWorkflow(ctx context.Context, tgzPath string, meta WorkflowMetadata, auth Auth) error
+	RegisterWorkflow(ctx context.Context, tgzPath string, meta WorkflowMetadata, auth Auth) ([]RegistrationResult, error)
+	ArchiveWorkflow(ctx context.Context, meta WorkflowMetadata, launchPlans []string, workflows []string, auth Auth) error
+	ActivateLaunchPlan(ctx context.Context, name string, meta WorkflowMetadata, auth Auth) error
+	DeactivateLaunchPlan(ctx context.Context, name string, meta WorkflowMetadata, auth Auth) error
+	GetProject(ctx context.Context, id string, auth Auth) (Project, error)
+	CreateProject(ctx context.Context, project Project, auth Auth) error
+	UpdateProject(ctx context.Context, project Project, auth Auth) error
+	ArchiveProject(ctx context.Context, id string, auth Auth) error
+	GetMatchableAttributes(ctx context.Context, project string, domain string, auth Auth) (MatchableAttributes, error)
+	UpdateMatchableAttributes(ctx context.Context, attributes MatchableAttributes, auth Auth) error
+	DeleteMatchableAttributes(ctx context.Context, project string, domain string, resources []MatchableResource, auth Auth) error
+	CreateExecution(ctx context.Context, request ExecutionRequest, auth Auth) (string, error)
+	GetExecution(ctx context.Context, project string, domain string, name string, auth Auth) (Execution, error)
+	TerminateExecution(ctx context.Context, project string, domain string, name string, auth Auth) error
 }
 
+// ErrProjectNotFound is returned when a project does not exist in flyte
+var ErrProjectNotFound = errors.New("project not found")
+
+// ErrRegistrationRejected is returned when flyte admin rejected entities of a workflow package, registering the same
+// package again fails the same way
+var ErrRegistrationRejected = errors.New("failed entities")
+
+// transientCodes are the gRPC status codes of the entities flyte admin failed to register while it was unavailable or
+// throttling, registering them again can succeed
+var transientCodes = []string{"code = Unavailable", "code = DeadlineExceeded", "code = ResourceExhausted", "code = Aborted"}
+
 // AdminClient is a wrapper for interactions with FlyteAdmin
 type AdminClient struct {
 	Executor command.Executor
+	// Limiter rate limits the flytectl commands sent to each flyte admin endpoint
+	Limiter *ratelimit.Limiter
 }
 
 // NewClient creates a new instance of flyte wrapper
-func NewClient(executor command.Executor) *AdminClient {
+func NewClient(executor command.Executor, limiter *ratelimit.Limiter) *AdminClient {
 	return &AdminClient{
 		Executor: executor,
+		Limiter:  limiter,
 	}
 }
 
@@ -51,15 +89,136 @@ type WorkflowMetadata struct {
 	Project         string
 }
 
+// ClientSecretEnvVar is the environment variable flytectl reads the client secret from
+const ClientSecretEnvVar = "FLYTE_CLIENT_SECRET"
+
 // Auth is a struct that contains the endpoint for the flyte admin server as well as the client credentials.
+// When ClientSecret is set it is passed to flytectl in the ClientSecretEnvVar environment variable, otherwise the
+// variable is expected to be set in the environment of the operator.
 type Auth struct {
 	AdminEndpoint      string
 	ClientID           string
 	ClientSecretEnvVar string
+	ClientSecret       string
+}
+
+// Identifier identifies a single version of a flyte entity, such as a workflow or a launch plan
+type Identifier struct {
+	ResourceType string `json:"resourceType"`
+	Project      string `json:"project"`
+	Domain       string `json:"domain"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+}
+
+// Resource types of the entities serialized by pyflyte, named as in the flyte admin API
+const (
+	ResourceTypeTask       = "TASK"
+	ResourceTypeWorkflow   = "WORKFLOW"
+	ResourceTypeLaunchPlan = "LAUNCH_PLAN"
+)
+
+// resourceTypeSuffixes maps the suffix pyflyte gives to serialized entity files to the resource type of the entity
+var resourceTypeSuffixes = map[string]string{
+	"1": ResourceTypeTask,
+	"2": ResourceTypeWorkflow,
+	"3": ResourceTypeLaunchPlan,
+}
+
+// RegistrationResult is the outcome of registering a single entity of a workflow package
+type RegistrationResult struct {
+	// Entity identifies the entity, it is only partially filled when the file name is not one produced by pyflyte
+	Entity Identifier
+	// File is the name of the file in the workflow package the entity was serialized to
+	File string
+	// Succeeded is true when the entity was registered
+	Succeeded bool
+	// Message is the additional information flytectl gives about the registration, such as the error
+	Message string
 }
 
-// RegisterWorkflow registers workflow using flytectl with a provided .tgz file.
-func (a *AdminClient) RegisterWorkflow(ctx context.Context, tgzPath string, meta WorkflowMetadata, auth Auth) error {
+// ProjectStateArchived is the state of an archived flyte project
+const ProjectStateArchived = "ARCHIVED"
+
+// Project is a flyte project
+type Project struct {
+	ID          string
+	Name        string
+	Description string
+	Labels      map[string]string
+	// State is the state of the project in flyte, empty for an active project
+	State string
+}
+
+// project is a flyte project as printed by flytectl
+type project struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Labels      struct {
+		Values map[string]string `json:"values"`
+	} `json:"labels"`
+	State string `json:"state"`
+}
+
+// entity is the part of a flyte entity printed by flytectl that we need to identify it
+type entity struct {
+	ID Identifier `json:"id"`
+}
+
+// args returns the flytectl arguments to connect to the flyte admin server
+func (a Auth) args() []string {
+	return []string{
+		"--admin.endpoint", a.AdminEndpoint,
+		"--admin.authType", "ClientSecret",
+		"--admin.clientId", a.ClientID,
+		"--admin.clientSecretEnvVar", a.ClientSecretEnvVar,
+	}
+}
+
+// flytectl executes flytectl with the given arguments, connecting to flyte admin with the given auth once the rate
+// limit of the admin endpoint allows it. The exit code is recorded in the metrics under the flytectl command, the first
+// argument.
+func (a *AdminClient) flytectl(ctx context.Context, auth Auth, args ...string) ([]byte, error) {
+	if err := a.Limiter.Wait(ctx, ratelimit.Host(auth.AdminEndpoint)); err != nil {
+		return nil, err
+	}
+
+	// The auth arguments are appended to a copy, so that they are not written into the spare capacity of the caller's
+	// slice
+	command := args[0]
+	args = append(slices.Clip(args), auth.args()...)
+
+	var output []byte
+	var err error
+	if auth.ClientSecret == "" {
+		output, err = a.Executor.ExecuteCommand(ctx, "flytectl", args...)
+	} else {
+		env := []string{fmt.Sprintf("%s=%s", auth.ClientSecretEnvVar, auth.ClientSecret)}
+		output, err = a.Executor.ExecuteCommandWithEnv(ctx, env, "flytectl", args...)
+	}
+
+	metrics.ObserveFlytectl(command, exitCode(err))
+	return output, err
+}
+
+// exitCode returns the exit code of a command from the error it returned, or -1 when the command did not run
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+
+	return -1
+}
+
+// RegisterWorkflow registers workflow using flytectl with a provided .tgz file, and returns the outcome of the
+// registration of each entity in the workflow package.
+func (a *AdminClient) RegisterWorkflow(ctx context.Context, tgzPath string, meta WorkflowMetadata, auth Auth) ([]RegistrationResult, error) {
 	args := []string{
 		"register",
 		"files",
@@ -67,14 +226,316 @@ func (a *AdminClient) RegisterWorkflow(ctx context.Context, tgzPath string, meta
 		"--project", meta.Project,
 		"--domain", meta.Domain,
 		"--version", meta.WorkflowVersion,
-		"--admin.endpoint", auth.AdminEndpoint,
-		"--admin.authType", "ClientSecret",
-		"--admin.clientId", auth.ClientID,
-		"--admin.clientSecretEnvVar", auth.ClientSecretEnvVar,
 	}
-	output, err := a.Executor.ExecuteCommand(ctx, "flytectl", args...)
+	output, err := a.flytectl(ctx, auth, args...)
+	results := parseRegistrationResults(output, meta)
+	if err != nil {
+		var failures []string
+		rejected := true
+		for _, result := range results {
+			if !result.Succeeded {
+				failures = append(failures, fmt.Sprintf("%s: %s", result.File, result.Message))
+				rejected = rejected && !slices.ContainsFunc(transientCodes, func(code string) bool {
+					return strings.Contains(result.Message, code)
+				})
+			}
+		}
+		if len(failures) == 0 {
+			return results, fmt.Errorf("failed to execute flytectl: %w, output: %s", err, output)
+		}
+		if !rejected {
+			return results, fmt.Errorf("failed to execute flytectl: %w, failed entities: %s", err, strings.Join(failures, "; "))
+		}
+		return results, fmt.Errorf("failed to execute flytectl: %w, %w: %s", err, ErrRegistrationRejected, strings.Join(failures, "; "))
+	}
+	return results, nil
+}
+
+// ArchiveWorkflow deactivates the named launch plans registered with the given version, and archives the named
+// workflows registered with it, so that nothing registered from the workflow package keeps running in flyte. Other
+// workflow packages may register entities with the same version into the project and domain, so only the entities
+// named by the caller are touched. Flyte archives a workflow by name, with all of its versions, so the caller leaves
+// out the workflows that are still in use.
+func (a *AdminClient) ArchiveWorkflow(ctx context.Context, meta WorkflowMetadata, launchPlans []string, workflows []string, auth Auth) error {
+	registeredLaunchPlans, err := a.listEntities(ctx, "launchplan", meta, auth)
+	if err != nil {
+		return fmt.Errorf("failed to list launch plans: %w", err)
+	}
+
+	for _, lp := range registeredLaunchPlans {
+		if !slices.Contains(launchPlans, lp.Name) {
+			continue
+		}
+
+		if err := a.DeactivateLaunchPlan(ctx, lp.Name, meta, auth); err != nil {
+			return err
+		}
+	}
+
+	registeredWorkflows, err := a.listEntities(ctx, "workflow", meta, auth)
+	if err != nil {
+		return fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	for _, wf := range registeredWorkflows {
+		if !slices.Contains(workflows, wf.Name) {
+			continue
+		}
+
+		args := []string{
+			"update",
+			"workflow-meta",
+			wf.Name,
+			"--project", meta.Project,
+			"--domain", meta.Domain,
+			"--archive",
+		}
+		output, err := a.flytectl(ctx, auth, args...)
+		if err != nil {
+			return fmt.Errorf("failed to archive workflow %s: %w, output: %s", wf.Name, err, output)
+		}
+	}
+
+	return nil
+}
+
+// ActivateLaunchPlan activates the version of a launch plan registered with the given version, flyte admin deactivates
+// the version of the launch plan that was active before
+func (a *AdminClient) ActivateLaunchPlan(ctx context.Context, name string, meta WorkflowMetadata, auth Auth) error {
+	return a.updateLaunchPlan(ctx, name, meta, "--activate", auth)
+}
+
+// DeactivateLaunchPlan deactivates the version of a launch plan registered with the given version, so that its
+// schedules and triggers stop launching executions
+func (a *AdminClient) DeactivateLaunchPlan(ctx context.Context, name string, meta WorkflowMetadata, auth Auth) error {
+	return a.updateLaunchPlan(ctx, name, meta, "--deactivate", auth)
+}
+
+// updateLaunchPlan sets the state of a version of a launch plan with the given flytectl flag
+func (a *AdminClient) updateLaunchPlan(ctx context.Context, name string, meta WorkflowMetadata, flag string, auth Auth) error {
+	args := []string{
+		"update",
+		"launchplan",
+		name,
+		"--project", meta.Project,
+		"--domain", meta.Domain,
+		"--version", meta.WorkflowVersion,
+		flag,
+	}
+	output, err := a.flytectl(ctx, auth, args...)
 	if err != nil {
-		return fmt.Errorf("failed to execute flytectl: %w, output: %s", err, output)
+		return fmt.Errorf("failed to %s launch plan %s: %w, output: %s", strings.TrimPrefix(flag, "--"), name, err, output)
 	}
+
 	return nil
 }
+
+// GetProject returns the project with the given id, or ErrProjectNotFound when it does not exist
+func (a *AdminClient) GetProject(ctx context.Context, id string, auth Auth) (Project, error) {
+	output, err := a.flytectl(ctx, auth, "get", "project", id, "--output", "json")
+	if err != nil {
+		if notFound(output) {
+			return Project{}, fmt.Errorf("%w: %s", ErrProjectNotFound, id)
+		}
+		return Project{}, fmt.Errorf("failed to execute flytectl: %w, output: %s", err, output)
+	}
+
+	return decodeProject(output)
+}
+
+// CreateProject creates a project with the id, name, description and labels of the given project
+func (a *AdminClient) CreateProject(ctx context.Context, p Project, auth Auth) error {
+	args := append([]string{"create", "project"}, projectArgs(p)...)
+	output, err := a.flytectl(ctx, auth, args...)
+	if err != nil {
+		return fmt.Errorf("failed to create project %s: %w, output: %s", p.ID, err, output)
+	}
+
+	return nil
+}
+
+// UpdateProject replaces the name, description and labels of a project with those of the given project, and activates
+// it again when it was archived
+func (a *AdminClient) UpdateProject(ctx context.Context, p Project, auth Auth) error {
+	args := append([]string{"update", "project"}, projectArgs(p)...)
+	args = append(args, "--activate", "--force")
+	output, err := a.flytectl(ctx, auth, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update project %s: %w, output: %s", p.ID, err, output)
+	}
+
+	return nil
+}
+
+// ArchiveProject archives a project, flyte does not delete projects
+func (a *AdminClient) ArchiveProject(ctx context.Context, id string, auth Auth) error {
+	output, err := a.flytectl(ctx, auth, "update", "project", "--id", id, "--archive", "--force")
+	if err != nil {
+		return fmt.Errorf("failed to archive project %s: %w, output: %s", id, err, output)
+	}
+
+	return nil
+}
+
+// notFound returns true when flytectl failed because flyte admin reported, with the gRPC NotFound code, that the entity
+// it was asked for does not exist. Other failures that mention "not found", such as a missing flytectl binary, are not
+// taken for a missing entity.
+func notFound(output []byte) bool {
+	return bytes.Contains(output, []byte("code = NotFound"))
+}
+
+// projectArgs returns the flytectl arguments that set the fields of a project, the labels are sorted by key so that
+// the arguments are stable
+func projectArgs(p Project) []string {
+	args := []string{
+		"--id", p.ID,
+		"--name", p.Name,
+		"--description", p.Description,
+	}
+
+	if len(p.Labels) > 0 {
+		labels := make([]string, 0, len(p.Labels))
+		for key, value := range p.Labels {
+			labels = append(labels, key+"="+value)
+		}
+		sort.Strings(labels)
+		args = append(args, "--labels", strings.Join(labels, ","))
+	}
+
+	return args
+}
+
+// listEntities lists the identifiers of the entities of a given kind that were registered with the given version
+func (a *AdminClient) listEntities(ctx context.Context, kind string, meta WorkflowMetadata, auth Auth) ([]Identifier, error) {
+	args := []string{
+		"get",
+		kind,
+		"--project", meta.Project,
+		"--domain", meta.Domain,
+		"--filter.fieldSelector", fmt.Sprintf("version=%s", meta.WorkflowVersion),
+		"--output", "json",
+	}
+	output, err := a.flytectl(ctx, auth, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute flytectl: %w, output: %s", err, output)
+	}
+
+	return decodeIdentifiers(output)
+}
+
+// parseRegistrationResults parses the table of results printed by flytectl register files. Each row holds the file of
+// an entity, its status and additional information, rows with an empty file continue the previous row.
+func parseRegistrationResults(output []byte, meta WorkflowMetadata) []RegistrationResult {
+	var results []RegistrationResult
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		columns := strings.Split(strings.TrimSpace(scanner.Text()), "|")
+		if len(columns) < 5 || columns[0] != "" {
+			continue
+		}
+
+		file := strings.TrimSpace(columns[1])
+		status := strings.TrimSpace(columns[2])
+		message := strings.TrimSpace(columns[3])
+
+		if strings.EqualFold(file, "name") && strings.EqualFold(status, "status") {
+			continue
+		}
+
+		if file == "" {
+			if len(results) > 0 && message != "" {
+				results[len(results)-1].Message += " " + message
+			}
+			continue
+		}
+
+		results = append(results, RegistrationResult{
+			Entity:    parseEntityFile(file, meta),
+			File:      filepath.Base(file),
+			Succeeded: strings.EqualFold(status, "success"),
+			Message:   message,
+		})
+	}
+
+	return results
+}
+
+// parseEntityFile identifies an entity from the name of the file pyflyte serialized it to, which has the form
+// <index>_<entity name>_<resource type>.pb
+func parseEntityFile(file string, meta WorkflowMetadata) Identifier {
+	id := Identifier{
+		Project: meta.Project,
+		Domain:  meta.Domain,
+		Version: meta.WorkflowVersion,
+	}
+
+	name := strings.TrimSuffix(filepath.Base(file), ".pb")
+	if _, rest, ok := strings.Cut(name, "_"); ok {
+		name = rest
+	}
+
+	if i := strings.LastIndex(name, "_"); i > 0 {
+		if resourceType, ok := resourceTypeSuffixes[name[i+1:]]; ok {
+			id.ResourceType = resourceType
+			name = name[:i]
+		}
+	}
+
+	id.Name = name
+	return id
+}
+
+// decodeIdentifiers decodes the identifiers of the entities printed by flytectl as json. flytectl prints a single
+// object when there is one entity and a list otherwise, and nothing at all when there are none.
+func decodeIdentifiers(output []byte) ([]Identifier, error) {
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return nil, nil
+	}
+
+	var entities []entity
+	if output[0] == '{' {
+		var e entity
+		if err := json.Unmarshal(output, &e); err != nil {
+			return nil, fmt.Errorf("failed to decode flytectl output: %w", err)
+		}
+		entities = append(entities, e)
+	} else if err := json.Unmarshal(output, &entities); err != nil {
+		return nil, fmt.Errorf("failed to decode flytectl output: %w", err)
+	}
+
+	identifiers := make([]Identifier, 0, len(entities))
+	for _, e := range entities {
+		identifiers = append(identifiers, e.ID)
+	}
+
+	return identifiers, nil
+}
+
+// decodeProject decodes a project printed by flytectl as json, flytectl prints a list when it lists several projects
+func decodeProject(output []byte) (Project, error) {
+	output = bytes.TrimSpace(output)
+
+	var p project
+	if len(output) > 0 && output[0] == '[' {
+		var projects []project
+		if err := json.Unmarshal(output, &projects); err != nil {
+			return Project{}, fmt.Errorf("failed to decode flytectl output: %w", err)
+		}
+		if len(projects) == 0 {
+			return Project{}, ErrProjectNotFound
+		}
+		p = projects[0]
+	} else if err := json.Unmarshal(output, &p); err != nil {
+		return Project{}, fmt.Errorf("failed to decode flytectl output: %w", err)
+	}
+
+	return Project{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Labels:      p.Labels.Values,
+		State:       p.State,
+	}, nil
+}
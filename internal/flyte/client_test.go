@@ -19,21 +19,25 @@ package flyte
 import (
 	"context"
 	"errors"
+	"os/exec"
 	"testing"
 
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/command/mocks"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/ratelimit"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestNewClient(t *testing.T) {
 	mockCommandExecutor := mocks.Executor{}
+	limiter := ratelimit.NewLimiter(5, 10)
 
 	expectedClient := AdminClient{
 		Executor: &mockCommandExecutor,
+		Limiter:  limiter,
 	}
 
-	c := NewClient(&mockCommandExecutor)
+	c := NewClient(&mockCommandExecutor, limiter)
 
 	assert.Equal(t, &expectedClient, c)
 }
@@ -76,17 +80,75 @@ func TestRegisterWorkflow(t *testing.T) {
 	// SHARED MOCKS
 	mockCommandExecutor := mocks.Executor{}
 
+	successOutput := []byte(` ----------------------------------------------- --------- ------------------------------ 
+| NAME                                          | STATUS  | ADDITIONAL INFO              |
+ ----------------------------------------------- --------- ------------------------------ 
+| /tmp/register/0_example.workflows.say_hi_1.pb | Success | Successfully registered file |
+ ----------------------------------------------- --------- ------------------------------ 
+| /tmp/register/1_example.workflows.wf_2.pb     | Success | Successfully registered file |
+ ----------------------------------------------- --------- ------------------------------ 
+| /tmp/register/2_example.workflows.wf_3.pb     | Success | Successfully registered file |
+ ----------------------------------------------- --------- ------------------------------ 
+3 rows
+`)
+
+	failureOutput := []byte(` ----------------------------------------------- --------- ------------------------------ 
+| NAME                                          | STATUS  | ADDITIONAL INFO              |
+ ----------------------------------------------- --------- ------------------------------ 
+| /tmp/register/0_example.workflows.say_hi_1.pb | Failed  | Error registering file due   |
+|                                               |         | to rpc error                 |
+ ----------------------------------------------- --------- ------------------------------ 
+1 rows
+`)
+
 	t.Run("success case", func(t *testing.T) {
 		// MOCK BEHAVIOUR
-		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(nil, nil).Once()
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(successOutput, nil).Once()
+
+		// EXECUTION
+		c := NewClient(&mockCommandExecutor, nil)
+
+		results, err := c.RegisterWorkflow(context.Background(), tgzPath, meta, flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, []RegistrationResult{
+			{
+				Entity:    Identifier{ResourceType: ResourceTypeTask, Project: "test-project", Domain: "test-domain", Name: "example.workflows.say_hi", Version: "1.0.0"},
+				File:      "0_example.workflows.say_hi_1.pb",
+				Succeeded: true,
+				Message:   "Successfully registered file",
+			},
+			{
+				Entity:    Identifier{ResourceType: ResourceTypeWorkflow, Project: "test-project", Domain: "test-domain", Name: "example.workflows.wf", Version: "1.0.0"},
+				File:      "1_example.workflows.wf_2.pb",
+				Succeeded: true,
+				Message:   "Successfully registered file",
+			},
+			{
+				Entity:    Identifier{ResourceType: ResourceTypeLaunchPlan, Project: "test-project", Domain: "test-domain", Name: "example.workflows.wf", Version: "1.0.0"},
+				File:      "2_example.workflows.wf_3.pb",
+				Succeeded: true,
+				Message:   "Successfully registered file",
+			},
+		}, results)
+	})
+
+	t.Run("success case: client secret passed in the environment", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		env := []string{"FLYTE_CLIENT_SECRET=test-client-secret"}
+		mockCommandExecutor.EXPECT().ExecuteCommandWithEnv(mock.Anything, env, command, args...).Return(successOutput, nil).Once()
 
 		// EXECUTION
-		c := NewClient(&mockCommandExecutor)
+		c := NewClient(&mockCommandExecutor, nil)
 
-		err := c.RegisterWorkflow(context.Background(), tgzPath, meta, flyteAuth)
+		authWithSecret := flyteAuth
+		authWithSecret.ClientSecret = "test-client-secret"
+		results, err := c.RegisterWorkflow(context.Background(), tgzPath, meta, authWithSecret)
 
 		// ASSERTIONS
 		assert.NoError(t, err)
+		assert.Len(t, results, 3)
 	})
 
 	t.Run("failure case: execute command error", func(t *testing.T) {
@@ -94,11 +156,397 @@ func TestRegisterWorkflow(t *testing.T) {
 		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(nil, errors.New("test error")).Once()
 
 		// EXECUTION
-		c := NewClient(&mockCommandExecutor)
+		c := NewClient(&mockCommandExecutor, nil)
 
-		err := c.RegisterWorkflow(context.Background(), tgzPath, meta, flyteAuth)
+		_, err := c.RegisterWorkflow(context.Background(), tgzPath, meta, flyteAuth)
 
 		// ASSERTIONS
 		assert.ErrorContains(t, err, "failed to execute flytectl")
+		assert.NotErrorIs(t, err, ErrRegistrationRejected)
 	})
+
+	t.Run("failure case: entity registration error", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(failureOutput, errors.New("exit status 1")).Once()
+
+		// EXECUTION
+		c := NewClient(&mockCommandExecutor, nil)
+
+		results, err := c.RegisterWorkflow(context.Background(), tgzPath, meta, flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "0_example.workflows.say_hi_1.pb: Error registering file due to rpc error")
+		assert.ErrorIs(t, err, ErrRegistrationRejected)
+		assert.Len(t, results, 1)
+		assert.False(t, results[0].Succeeded)
+	})
+
+	t.Run("failure case: entity registration error while flyte admin is unavailable", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		unavailableOutput := []byte(` ----------------------------------------------- --------- ------------------------------ 
+| NAME                                          | STATUS  | ADDITIONAL INFO              |
+ ----------------------------------------------- --------- ------------------------------ 
+| /tmp/register/0_example.workflows.say_hi_1.pb | Failed  | Error registering file due   |
+|                                               |         | to rpc error: code =         |
+|                                               |         | Unavailable desc = timeout   |
+ ----------------------------------------------- --------- ------------------------------ 
+1 rows
+`)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(unavailableOutput, errors.New("exit status 1")).Once()
+
+		// EXECUTION
+		c := NewClient(&mockCommandExecutor, nil)
+
+		_, err := c.RegisterWorkflow(context.Background(), tgzPath, meta, flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed entities: 0_example.workflows.say_hi_1.pb")
+		assert.NotErrorIs(t, err, ErrRegistrationRejected)
+	})
+}
+
+func TestArchiveWorkflow(t *testing.T) {
+	// SHARED INPUTS
+	command := "flytectl"
+
+	meta := WorkflowMetadata{
+		WorkflowVersion: "1.0.0",
+		Domain:          "test-domain",
+		Project:         "test-project",
+	}
+
+	flyteAuth := Auth{
+		AdminEndpoint:      "test-endpoint",
+		ClientID:           "test-client-id",
+		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	}
+
+	authArgs := []interface{}{
+		"--admin.endpoint", "test-endpoint",
+		"--admin.authType", "ClientSecret",
+		"--admin.clientId", "test-client-id",
+		"--admin.clientSecretEnvVar", "FLYTE_CLIENT_SECRET",
+	}
+
+	listArgs := func(kind string) []interface{} {
+		return append([]interface{}{
+			"get", kind,
+			"--project", meta.Project,
+			"--domain", meta.Domain,
+			"--filter.fieldSelector", "version=1.0.0",
+			"--output", "json",
+		}, authArgs...)
+	}
+
+	deactivateArgs := append([]interface{}{
+		"update", "launchplan", "test-launchplan",
+		"--project", meta.Project,
+		"--domain", meta.Domain,
+		"--version", meta.WorkflowVersion,
+		"--deactivate",
+	}, authArgs...)
+
+	// Workflows are archived by name, there is no version to archive on its own
+	archiveArgs := append([]interface{}{
+		"update", "workflow-meta", "test-workflow",
+		"--project", meta.Project,
+		"--domain", meta.Domain,
+		"--archive",
+	}, authArgs...)
+
+	launchPlans := []byte(`{"id": {"resourceType": "LAUNCH_PLAN", "project": "test-project", "domain": "test-domain", "name": "test-launchplan", "version": "1.0.0"}}`)
+	workflows := []byte(`[{"id": {"resourceType": "WORKFLOW", "project": "test-project", "domain": "test-domain", "name": "test-workflow", "version": "1.0.0"}}, {"id": {"resourceType": "WORKFLOW", "project": "test-project", "domain": "test-domain", "name": "other-workflow", "version": "1.0.0"}}]`)
+
+	t.Run("success case", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		// other-workflow was registered with the same version from another workflow package, so it is not archived
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, listArgs("launchplan")...).Return(launchPlans, nil).Once()
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, deactivateArgs...).Return(nil, nil).Once()
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, listArgs("workflow")...).Return(workflows, nil).Once()
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, archiveArgs...).Return(nil, nil).Once()
+
+		// EXECUTION
+		c := NewClient(mockCommandExecutor, nil)
+
+		err := c.ArchiveWorkflow(context.Background(), meta, []string{"test-launchplan"}, []string{"test-workflow"}, flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("success case: entities that are not named are left untouched", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		// The executor does not expect anything to be deactivated or archived
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, listArgs("launchplan")...).Return(launchPlans, nil).Once()
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, listArgs("workflow")...).Return(workflows, nil).Once()
+
+		// EXECUTION
+		c := NewClient(mockCommandExecutor, nil)
+
+		err := c.ArchiveWorkflow(context.Background(), meta, []string{"other-launchplan"}, nil, flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("success case: nothing registered with the version", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, listArgs("launchplan")...).Return([]byte("[]"), nil).Once()
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, listArgs("workflow")...).Return(nil, nil).Once()
+
+		// EXECUTION
+		c := NewClient(mockCommandExecutor, nil)
+
+		err := c.ArchiveWorkflow(context.Background(), meta, []string{"test-launchplan"}, []string{"test-workflow"}, flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: deactivate launch plan error", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, listArgs("launchplan")...).Return(launchPlans, nil).Once()
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, deactivateArgs...).Return(nil, errors.New("test error")).Once()
+
+		// EXECUTION
+		c := NewClient(mockCommandExecutor, nil)
+
+		err := c.ArchiveWorkflow(context.Background(), meta, []string{"test-launchplan"}, []string{"test-workflow"}, flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to deactivate launch plan test-launchplan")
+	})
+}
+
+func TestUpdateLaunchPlan(t *testing.T) {
+	// SHARED INPUTS
+	command := "flytectl"
+
+	meta := WorkflowMetadata{
+		WorkflowVersion: "1.0.0",
+		Domain:          "test-domain",
+		Project:         "test-project",
+	}
+
+	flyteAuth := Auth{
+		AdminEndpoint:      "test-endpoint",
+		ClientID:           "test-client-id",
+		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	}
+
+	updateArgs := func(flag string) []interface{} {
+		return append([]interface{}{
+			"update", "launchplan", "test-launchplan",
+			"--project", meta.Project,
+			"--domain", meta.Domain,
+			"--version", meta.WorkflowVersion,
+			flag,
+		}, stringArgs(flyteAuth.args())...)
+	}
+
+	t.Run("activate", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, updateArgs("--activate")...).Return(nil, nil).Once()
+
+		// EXECUTION
+		err := NewClient(mockCommandExecutor, nil).ActivateLaunchPlan(context.Background(), "test-launchplan", meta, flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: deactivate", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, updateArgs("--deactivate")...).
+			Return([]byte("not found"), errors.New("test error")).Once()
+
+		// EXECUTION
+		err := NewClient(mockCommandExecutor, nil).DeactivateLaunchPlan(context.Background(), "test-launchplan", meta, flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to deactivate launch plan test-launchplan: test error, output: not found")
+	})
+}
+
+func TestGetProject(t *testing.T) {
+	// SHARED INPUTS
+	command := "flytectl"
+
+	flyteAuth := Auth{
+		AdminEndpoint:      "test-endpoint",
+		ClientID:           "test-client-id",
+		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	}
+
+	args := append([]interface{}{"get", "project", "test-project", "--output", "json"}, stringArgs(flyteAuth.args())...)
+
+	t.Run("success case", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		output := []byte(`{"id": "test-project", "name": "Test project", "description": "test", "labels": {"values": {"team": "data"}}, "state": "ARCHIVED"}`)
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(output, nil).Once()
+
+		// EXECUTION
+		c := NewClient(mockCommandExecutor, nil)
+
+		project, err := c.GetProject(context.Background(), "test-project", flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, Project{
+			ID:          "test-project",
+			Name:        "Test project",
+			Description: "test",
+			Labels:      map[string]string{"team": "data"},
+			State:       ProjectStateArchived,
+		}, project)
+	})
+
+	t.Run("failure case: project does not exist", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		output := []byte(`Error: rpc error: code = NotFound desc = missing entity of type PROJECT with identifier project:"test-project"`)
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(output, errors.New("exit status 1")).Once()
+
+		// EXECUTION
+		c := NewClient(mockCommandExecutor, nil)
+
+		_, err := c.GetProject(context.Background(), "test-project", flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorIs(t, err, ErrProjectNotFound)
+	})
+
+	t.Run("failure case: flytectl is missing", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		output := []byte(`sh: flytectl: command not found`)
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).
+			Return(output, &exec.Error{Name: "flytectl", Err: exec.ErrNotFound}).Once()
+
+		// EXECUTION
+		c := NewClient(mockCommandExecutor, nil)
+
+		_, err := c.GetProject(context.Background(), "test-project", flyteAuth)
+
+		// ASSERTIONS
+		assert.NotErrorIs(t, err, ErrProjectNotFound)
+		assert.ErrorContains(t, err, "failed to execute flytectl")
+	})
+}
+
+func TestManageProject(t *testing.T) {
+	// SHARED INPUTS
+	command := "flytectl"
+
+	flyteAuth := Auth{
+		AdminEndpoint:      "test-endpoint",
+		ClientID:           "test-client-id",
+		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	}
+	authArgs := stringArgs(flyteAuth.args())
+
+	project := Project{
+		ID:          "test-project",
+		Name:        "Test project",
+		Description: "test",
+		Labels:      map[string]string{"team": "data", "cost-centre": "42"},
+	}
+	projectArgs := []interface{}{
+		"--id", "test-project",
+		"--name", "Test project",
+		"--description", "test",
+		"--labels", "cost-centre=42,team=data",
+	}
+
+	t.Run("create", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		args := append(append([]interface{}{"create", "project"}, projectArgs...), authArgs...)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(nil, nil).Once()
+
+		// EXECUTION
+		err := NewClient(mockCommandExecutor, nil).CreateProject(context.Background(), project, flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		args := append(append(append([]interface{}{"update", "project"}, projectArgs...), "--activate", "--force"), authArgs...)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(nil, nil).Once()
+
+		// EXECUTION
+		err := NewClient(mockCommandExecutor, nil).UpdateProject(context.Background(), project, flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: archive", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		args := append([]interface{}{"update", "project", "--id", "test-project", "--archive", "--force"}, authArgs...)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(nil, errors.New("test error")).Once()
+
+		// EXECUTION
+		err := NewClient(mockCommandExecutor, nil).ArchiveProject(context.Background(), "test-project", flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to archive project test-project: test error")
+	})
+}
+
+// stringArgs converts command arguments to the arguments of a mocked command executor call
+func stringArgs(args []string) []interface{} {
+	converted := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		converted = append(converted, arg)
+	}
+	return converted
+}
+
+func TestFlytectl(t *testing.T) {
+	t.Run("the arguments of the caller are left as they are", func(t *testing.T) {
+		// SHARED INPUTS
+		flyteAuth := Auth{
+			AdminEndpoint:      "test-endpoint",
+			ClientID:           "test-client-id",
+			ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+		}
+
+		// The slice has spare capacity, appending to it in place would overwrite the arguments after it
+		args := make([]string, 2, 16)
+		copy(args, []string{"get", "project"})
+
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, "flytectl", append([]interface{}{"get", "project"}, stringArgs(flyteAuth.args())...)...).
+			Return(nil, nil).Once()
+
+		// EXECUTION
+		c := NewClient(mockCommandExecutor, nil)
+
+		_, err := c.flytectl(context.Background(), flyteAuth, args...)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, make([]string, 14), args[2:cap(args)])
+	})
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, exitCode(nil))
+	assert.Equal(t, -1, exitCode(errors.New("executable file not found")))
+
+	err := exec.Command("sh", "-c", "exit 3").Run()
+	assert.Equal(t, 3, exitCode(err))
 }
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flyte
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// Phases of flyte executions that have completed, named as in the flyte admin API
+const (
+	ExecutionPhaseSucceeded = "SUCCEEDED"
+	ExecutionPhaseFailed    = "FAILED"
+	ExecutionPhaseAborted   = "ABORTED"
+	ExecutionPhaseTimedOut  = "TIMED_OUT"
+)
+
+// ExecutionRequest is an execution of a version of a launch plan
+type ExecutionRequest struct {
+	LaunchPlan string
+	// Meta is the project and domain the execution is launched in, and the version of the launch plan
+	Meta WorkflowMetadata
+	// Inputs are the inputs of the execution by name
+	Inputs map[string]any
+}
+
+// Execution is the state of a flyte execution
+type Execution struct {
+	Name  string
+	Phase string
+	// Error is the message of the error the execution failed with
+	Error string
+}
+
+// Completed returns true when the execution has reached a phase it does not leave
+func (e Execution) Completed() bool {
+	switch e.Phase {
+	case ExecutionPhaseSucceeded, ExecutionPhaseFailed, ExecutionPhaseAborted, ExecutionPhaseTimedOut:
+		return true
+	}
+
+	return false
+}
+
+// execution is the part of a flyte execution printed by flytectl that we need
+type execution struct {
+	ID struct {
+		Name string `json:"name"`
+	} `json:"id"`
+	Closure struct {
+		Phase string `json:"phase"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"closure"`
+}
+
+// executionName matches the name of the execution in the identifier flytectl prints once it has created it
+var executionName = regexp.MustCompile(`name:"([^"]+)"`)
+
+// CreateExecution launches an execution of a version of a launch plan, and returns the name of the execution
+func (a *AdminClient) CreateExecution(ctx context.Context, request ExecutionRequest, auth Auth) (string, error) {
+	file, err := writeExecutionFile(request)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file)
+
+	args := []string{
+		"create",
+		"execution",
+		"--project", request.Meta.Project,
+		"--domain", request.Meta.Domain,
+		"--execFile", file,
+	}
+	output, err := a.flytectl(ctx, auth, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to create execution of launch plan %s: %w, output: %s", request.LaunchPlan, err, output)
+	}
+
+	match := executionName.FindSubmatch(output)
+	if match == nil {
+		return "", fmt.Errorf("failed to find the execution of launch plan %s in flytectl output: %s", request.LaunchPlan, output)
+	}
+
+	return string(match[1]), nil
+}
+
+// GetExecution returns the state of an execution in a project and domain
+func (a *AdminClient) GetExecution(ctx context.Context, project string, domain string, name string, auth Auth) (Execution, error) {
+	args := []string{
+		"get",
+		"execution",
+		name,
+		"--project", project,
+		"--domain", domain,
+		"--output", "json",
+	}
+	output, err := a.flytectl(ctx, auth, args...)
+	if err != nil {
+		return Execution{}, fmt.Errorf("failed to get execution %s: %w, output: %s", name, err, output)
+	}
+
+	return decodeExecution(output)
+}
+
+// TerminateExecution terminates an execution in a project and domain, an execution that has already completed is left
+// as it is
+func (a *AdminClient) TerminateExecution(ctx context.Context, project string, domain string, name string, auth Auth) error {
+	output, err := a.flytectl(ctx, auth, "delete", "execution", name, "--project", project, "--domain", domain)
+	if err != nil {
+		return fmt.Errorf("failed to terminate execution %s: %w, output: %s", name, err, output)
+	}
+
+	return nil
+}
+
+// writeExecutionFile writes an execution to a temporary flytectl execution file, flytectl reads the file as yaml so it
+// is written as json
+func writeExecutionFile(request ExecutionRequest) (string, error) {
+	inputs := request.Inputs
+	if inputs == nil {
+		inputs = map[string]any{}
+	}
+
+	encoded, err := json.Marshal(map[string]any{
+		"launchPlan": request.LaunchPlan,
+		"version":    request.Meta.WorkflowVersion,
+		"inputs":     inputs,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode execution: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "execution-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create execution file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(encoded); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write execution file: %w", err)
+	}
+
+	return file.Name(), nil
+}
+
+// decodeExecution decodes an execution printed by flytectl as json, flytectl prints a list when it lists several
+// executions
+func decodeExecution(output []byte) (Execution, error) {
+	output = bytes.TrimSpace(output)
+
+	var e execution
+	if len(output) > 0 && output[0] == '[' {
+		var executions []execution
+		if err := json.Unmarshal(output, &executions); err != nil {
+			return Execution{}, fmt.Errorf("failed to decode flytectl output: %w", err)
+		}
+		if len(executions) == 0 {
+			return Execution{}, fmt.Errorf("flytectl did not print the execution")
+		}
+		e = executions[0]
+	} else if err := json.Unmarshal(output, &e); err != nil {
+		return Execution{}, fmt.Errorf("failed to decode flytectl output: %w", err)
+	}
+
+	return Execution{
+		Name:  e.ID.Name,
+		Phase: e.Closure.Phase,
+		Error: e.Closure.Error.Message,
+	}, nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flyte
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/command/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateExecution(t *testing.T) {
+	// SHARED INPUTS
+	command := "flytectl"
+
+	flyteAuth := Auth{
+		AdminEndpoint:      "test-endpoint",
+		ClientID:           "test-client-id",
+		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	}
+	authArgs := stringArgs(flyteAuth.args())
+
+	args := append([]interface{}{"create", "execution", "--project", "test-project", "--domain", "development",
+		"--execFile", mock.AnythingOfType("string")}, authArgs...)
+	request := ExecutionRequest{
+		LaunchPlan: "test.smoke",
+		Meta:       WorkflowMetadata{WorkflowVersion: "1.0.0", Domain: "development", Project: "test-project"},
+		Inputs:     map[string]any{"count": 2},
+	}
+
+	t.Run("success case", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		var file string
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).
+			Run(func(_ context.Context, _ string, args ...string) {
+				contents, err := os.ReadFile(args[7])
+				assert.NoError(t, err)
+				file = string(contents)
+			}).
+			Return([]byte(`execution identifier project:"test-project" domain:"development" name:"f652ea3596e7f4d80a0e"`), nil).Once()
+
+		// EXECUTION
+		name, err := NewClient(mockCommandExecutor, nil).CreateExecution(context.Background(), request, flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, "f652ea3596e7f4d80a0e", name)
+		assert.Equal(t, `{"inputs":{"count":2},"launchPlan":"test.smoke","version":"1.0.0"}`, file)
+	})
+
+	t.Run("failure case: flytectl fails", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(nil, errors.New("test error")).Once()
+
+		// EXECUTION
+		_, err := NewClient(mockCommandExecutor, nil).CreateExecution(context.Background(), request, flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to create execution of launch plan test.smoke: test error")
+	})
+
+	t.Run("failure case: no execution in the output", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return([]byte("unexpected"), nil).Once()
+
+		// EXECUTION
+		_, err := NewClient(mockCommandExecutor, nil).CreateExecution(context.Background(), request, flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to find the execution of launch plan test.smoke")
+	})
+}
+
+func TestGetExecution(t *testing.T) {
+	// SHARED INPUTS
+	command := "flytectl"
+
+	flyteAuth := Auth{
+		AdminEndpoint:      "test-endpoint",
+		ClientID:           "test-client-id",
+		ClientSecretEnvVar: "FLYTE_CLIENT_SECRET",
+	}
+	authArgs := stringArgs(flyteAuth.args())
+
+	args := append([]interface{}{"get", "execution", "test-execution", "--project", "test-project", "--domain", "development",
+		"--output", "json"}, authArgs...)
+
+	t.Run("success case", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		output := []byte(`{"id": {"project": "test-project", "domain": "development", "name": "test-execution"},
+			"closure": {"phase": "FAILED", "error": {"code": "USER:Unknown", "message": "division by zero"}}}`)
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(output, nil).Once()
+
+		// EXECUTION
+		execution, err := NewClient(mockCommandExecutor, nil).GetExecution(context.Background(), "test-project", "development", "test-execution", flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, Execution{Name: "test-execution", Phase: ExecutionPhaseFailed, Error: "division by zero"}, execution)
+		assert.True(t, execution.Completed())
+	})
+
+	t.Run("success case: running execution", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		output := []byte(`[{"id": {"name": "test-execution"}, "closure": {"phase": "RUNNING"}}]`)
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(output, nil).Once()
+
+		// EXECUTION
+		execution, err := NewClient(mockCommandExecutor, nil).GetExecution(context.Background(), "test-project", "development", "test-execution", flyteAuth)
+
+		// ASSERTIONS
+		assert.NoError(t, err)
+		assert.Equal(t, Execution{Name: "test-execution", Phase: "RUNNING"}, execution)
+		assert.False(t, execution.Completed())
+	})
+
+	t.Run("failure case", func(t *testing.T) {
+		// MOCK BEHAVIOUR
+		mockCommandExecutor := mocks.NewExecutor(t)
+		mockCommandExecutor.EXPECT().ExecuteCommand(mock.Anything, command, args...).Return(nil, errors.New("test error")).Once()
+
+		// EXECUTION
+		_, err := NewClient(mockCommandExecutor, nil).GetExecution(context.Background(), "test-project", "development", "test-execution", flyteAuth)
+
+		// ASSERTIONS
+		assert.ErrorContains(t, err, "failed to get execution test-execution: test error")
+	})
+}
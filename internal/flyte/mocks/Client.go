@@ -22,17 +22,114 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
-// RegisterWorkflow provides a mock function with given fields: ctx, tgzPath, meta, auth
-func (_m *Client) RegisterWorkflow(ctx context.Context, tgzPath string, meta flyte.WorkflowMetadata, auth flyte.Auth) error {
-	ret := _m.Called(ctx, tgzPath, meta, auth)
+// ActivateLaunchPlan provides a mock function with given fields: ctx, name, meta, auth
+func (_m *Client) ActivateLaunchPlan(ctx context.Context, name string, meta flyte.WorkflowMetadata, auth flyte.Auth) error {
+	ret := _m.Called(ctx, name, meta, auth)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RegisterWorkflow")
+		panic("no return value specified for ActivateLaunchPlan")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, flyte.WorkflowMetadata, flyte.Auth) error); ok {
-		r0 = rf(ctx, tgzPath, meta, auth)
+		r0 = rf(ctx, name, meta, auth)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_ActivateLaunchPlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActivateLaunchPlan'
+type Client_ActivateLaunchPlan_Call struct {
+	*mock.Call
+}
+
+// ActivateLaunchPlan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - meta flyte.WorkflowMetadata
+//   - auth flyte.Auth
+func (_e *Client_Expecter) ActivateLaunchPlan(ctx interface{}, name interface{}, meta interface{}, auth interface{}) *Client_ActivateLaunchPlan_Call {
+	return &Client_ActivateLaunchPlan_Call{Call: _e.mock.On("ActivateLaunchPlan", ctx, name, meta, auth)}
+}
+
+func (_c *Client_ActivateLaunchPlan_Call) Run(run func(ctx context.Context, name string, meta flyte.WorkflowMetadata, auth flyte.Auth)) *Client_ActivateLaunchPlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(flyte.WorkflowMetadata), args[3].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_ActivateLaunchPlan_Call) Return(_a0 error) *Client_ActivateLaunchPlan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_ActivateLaunchPlan_Call) RunAndReturn(run func(context.Context, string, flyte.WorkflowMetadata, flyte.Auth) error) *Client_ActivateLaunchPlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArchiveProject provides a mock function with given fields: ctx, id, auth
+func (_m *Client) ArchiveProject(ctx context.Context, id string, auth flyte.Auth) error {
+	ret := _m.Called(ctx, id, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveProject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, flyte.Auth) error); ok {
+		r0 = rf(ctx, id, auth)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_ArchiveProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveProject'
+type Client_ArchiveProject_Call struct {
+	*mock.Call
+}
+
+// ArchiveProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - auth flyte.Auth
+func (_e *Client_Expecter) ArchiveProject(ctx interface{}, id interface{}, auth interface{}) *Client_ArchiveProject_Call {
+	return &Client_ArchiveProject_Call{Call: _e.mock.On("ArchiveProject", ctx, id, auth)}
+}
+
+func (_c *Client_ArchiveProject_Call) Run(run func(ctx context.Context, id string, auth flyte.Auth)) *Client_ArchiveProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_ArchiveProject_Call) Return(_a0 error) *Client_ArchiveProject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_ArchiveProject_Call) RunAndReturn(run func(context.Context, string, flyte.Auth) error) *Client_ArchiveProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArchiveWorkflow provides a mock function with given fields: ctx, meta, launchPlans, workflows, auth
+func (_m *Client) ArchiveWorkflow(ctx context.Context, meta flyte.WorkflowMetadata, launchPlans []string, workflows []string, auth flyte.Auth) error {
+	ret := _m.Called(ctx, meta, launchPlans, workflows, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveWorkflow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, flyte.WorkflowMetadata, []string, []string, flyte.Auth) error); ok {
+		r0 = rf(ctx, meta, launchPlans, workflows, auth)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -40,6 +137,450 @@ func (_m *Client) RegisterWorkflow(ctx context.Context, tgzPath string, meta fly
 	return r0
 }
 
+// Client_ArchiveWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveWorkflow'
+type Client_ArchiveWorkflow_Call struct {
+	*mock.Call
+}
+
+// ArchiveWorkflow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - meta flyte.WorkflowMetadata
+//   - launchPlans []string
+//   - workflows []string
+//   - auth flyte.Auth
+func (_e *Client_Expecter) ArchiveWorkflow(ctx interface{}, meta interface{}, launchPlans interface{}, workflows interface{}, auth interface{}) *Client_ArchiveWorkflow_Call {
+	return &Client_ArchiveWorkflow_Call{Call: _e.mock.On("ArchiveWorkflow", ctx, meta, launchPlans, workflows, auth)}
+}
+
+func (_c *Client_ArchiveWorkflow_Call) Run(run func(ctx context.Context, meta flyte.WorkflowMetadata, launchPlans []string, workflows []string, auth flyte.Auth)) *Client_ArchiveWorkflow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(flyte.WorkflowMetadata), args[2].([]string), args[3].([]string), args[4].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_ArchiveWorkflow_Call) Return(_a0 error) *Client_ArchiveWorkflow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_ArchiveWorkflow_Call) RunAndReturn(run func(context.Context, flyte.WorkflowMetadata, []string, []string, flyte.Auth) error) *Client_ArchiveWorkflow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateExecution provides a mock function with given fields: ctx, request, auth
+func (_m *Client) CreateExecution(ctx context.Context, request flyte.ExecutionRequest, auth flyte.Auth) (string, error) {
+	ret := _m.Called(ctx, request, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateExecution")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flyte.ExecutionRequest, flyte.Auth) (string, error)); ok {
+		return rf(ctx, request, auth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flyte.ExecutionRequest, flyte.Auth) string); ok {
+		r0 = rf(ctx, request, auth)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flyte.ExecutionRequest, flyte.Auth) error); ok {
+		r1 = rf(ctx, request, auth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_CreateExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateExecution'
+type Client_CreateExecution_Call struct {
+	*mock.Call
+}
+
+// CreateExecution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request flyte.ExecutionRequest
+//   - auth flyte.Auth
+func (_e *Client_Expecter) CreateExecution(ctx interface{}, request interface{}, auth interface{}) *Client_CreateExecution_Call {
+	return &Client_CreateExecution_Call{Call: _e.mock.On("CreateExecution", ctx, request, auth)}
+}
+
+func (_c *Client_CreateExecution_Call) Run(run func(ctx context.Context, request flyte.ExecutionRequest, auth flyte.Auth)) *Client_CreateExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(flyte.ExecutionRequest), args[2].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_CreateExecution_Call) Return(_a0 string, _a1 error) *Client_CreateExecution_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_CreateExecution_Call) RunAndReturn(run func(context.Context, flyte.ExecutionRequest, flyte.Auth) (string, error)) *Client_CreateExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateProject provides a mock function with given fields: ctx, project, auth
+func (_m *Client) CreateProject(ctx context.Context, project flyte.Project, auth flyte.Auth) error {
+	ret := _m.Called(ctx, project, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, flyte.Project, flyte.Auth) error); ok {
+		r0 = rf(ctx, project, auth)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_CreateProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProject'
+type Client_CreateProject_Call struct {
+	*mock.Call
+}
+
+// CreateProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - project flyte.Project
+//   - auth flyte.Auth
+func (_e *Client_Expecter) CreateProject(ctx interface{}, project interface{}, auth interface{}) *Client_CreateProject_Call {
+	return &Client_CreateProject_Call{Call: _e.mock.On("CreateProject", ctx, project, auth)}
+}
+
+func (_c *Client_CreateProject_Call) Run(run func(ctx context.Context, project flyte.Project, auth flyte.Auth)) *Client_CreateProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(flyte.Project), args[2].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_CreateProject_Call) Return(_a0 error) *Client_CreateProject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_CreateProject_Call) RunAndReturn(run func(context.Context, flyte.Project, flyte.Auth) error) *Client_CreateProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeactivateLaunchPlan provides a mock function with given fields: ctx, name, meta, auth
+func (_m *Client) DeactivateLaunchPlan(ctx context.Context, name string, meta flyte.WorkflowMetadata, auth flyte.Auth) error {
+	ret := _m.Called(ctx, name, meta, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateLaunchPlan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, flyte.WorkflowMetadata, flyte.Auth) error); ok {
+		r0 = rf(ctx, name, meta, auth)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_DeactivateLaunchPlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeactivateLaunchPlan'
+type Client_DeactivateLaunchPlan_Call struct {
+	*mock.Call
+}
+
+// DeactivateLaunchPlan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - meta flyte.WorkflowMetadata
+//   - auth flyte.Auth
+func (_e *Client_Expecter) DeactivateLaunchPlan(ctx interface{}, name interface{}, meta interface{}, auth interface{}) *Client_DeactivateLaunchPlan_Call {
+	return &Client_DeactivateLaunchPlan_Call{Call: _e.mock.On("DeactivateLaunchPlan", ctx, name, meta, auth)}
+}
+
+func (_c *Client_DeactivateLaunchPlan_Call) Run(run func(ctx context.Context, name string, meta flyte.WorkflowMetadata, auth flyte.Auth)) *Client_DeactivateLaunchPlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(flyte.WorkflowMetadata), args[3].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_DeactivateLaunchPlan_Call) Return(_a0 error) *Client_DeactivateLaunchPlan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_DeactivateLaunchPlan_Call) RunAndReturn(run func(context.Context, string, flyte.WorkflowMetadata, flyte.Auth) error) *Client_DeactivateLaunchPlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMatchableAttributes provides a mock function with given fields: ctx, project, domain, resources, auth
+func (_m *Client) DeleteMatchableAttributes(ctx context.Context, project string, domain string, resources []flyte.MatchableResource, auth flyte.Auth) error {
+	ret := _m.Called(ctx, project, domain, resources, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMatchableAttributes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []flyte.MatchableResource, flyte.Auth) error); ok {
+		r0 = rf(ctx, project, domain, resources, auth)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_DeleteMatchableAttributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMatchableAttributes'
+type Client_DeleteMatchableAttributes_Call struct {
+	*mock.Call
+}
+
+// DeleteMatchableAttributes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - project string
+//   - domain string
+//   - resources []flyte.MatchableResource
+//   - auth flyte.Auth
+func (_e *Client_Expecter) DeleteMatchableAttributes(ctx interface{}, project interface{}, domain interface{}, resources interface{}, auth interface{}) *Client_DeleteMatchableAttributes_Call {
+	return &Client_DeleteMatchableAttributes_Call{Call: _e.mock.On("DeleteMatchableAttributes", ctx, project, domain, resources, auth)}
+}
+
+func (_c *Client_DeleteMatchableAttributes_Call) Run(run func(ctx context.Context, project string, domain string, resources []flyte.MatchableResource, auth flyte.Auth)) *Client_DeleteMatchableAttributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]flyte.MatchableResource), args[4].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_DeleteMatchableAttributes_Call) Return(_a0 error) *Client_DeleteMatchableAttributes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_DeleteMatchableAttributes_Call) RunAndReturn(run func(context.Context, string, string, []flyte.MatchableResource, flyte.Auth) error) *Client_DeleteMatchableAttributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExecution provides a mock function with given fields: ctx, project, domain, name, auth
+func (_m *Client) GetExecution(ctx context.Context, project string, domain string, name string, auth flyte.Auth) (flyte.Execution, error) {
+	ret := _m.Called(ctx, project, domain, name, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExecution")
+	}
+
+	var r0 flyte.Execution
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, flyte.Auth) (flyte.Execution, error)); ok {
+		return rf(ctx, project, domain, name, auth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, flyte.Auth) flyte.Execution); ok {
+		r0 = rf(ctx, project, domain, name, auth)
+	} else {
+		r0 = ret.Get(0).(flyte.Execution)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, flyte.Auth) error); ok {
+		r1 = rf(ctx, project, domain, name, auth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExecution'
+type Client_GetExecution_Call struct {
+	*mock.Call
+}
+
+// GetExecution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - project string
+//   - domain string
+//   - name string
+//   - auth flyte.Auth
+func (_e *Client_Expecter) GetExecution(ctx interface{}, project interface{}, domain interface{}, name interface{}, auth interface{}) *Client_GetExecution_Call {
+	return &Client_GetExecution_Call{Call: _e.mock.On("GetExecution", ctx, project, domain, name, auth)}
+}
+
+func (_c *Client_GetExecution_Call) Run(run func(ctx context.Context, project string, domain string, name string, auth flyte.Auth)) *Client_GetExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_GetExecution_Call) Return(_a0 flyte.Execution, _a1 error) *Client_GetExecution_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetExecution_Call) RunAndReturn(run func(context.Context, string, string, string, flyte.Auth) (flyte.Execution, error)) *Client_GetExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMatchableAttributes provides a mock function with given fields: ctx, project, domain, auth
+func (_m *Client) GetMatchableAttributes(ctx context.Context, project string, domain string, auth flyte.Auth) (flyte.MatchableAttributes, error) {
+	ret := _m.Called(ctx, project, domain, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMatchableAttributes")
+	}
+
+	var r0 flyte.MatchableAttributes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, flyte.Auth) (flyte.MatchableAttributes, error)); ok {
+		return rf(ctx, project, domain, auth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, flyte.Auth) flyte.MatchableAttributes); ok {
+		r0 = rf(ctx, project, domain, auth)
+	} else {
+		r0 = ret.Get(0).(flyte.MatchableAttributes)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, flyte.Auth) error); ok {
+		r1 = rf(ctx, project, domain, auth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetMatchableAttributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMatchableAttributes'
+type Client_GetMatchableAttributes_Call struct {
+	*mock.Call
+}
+
+// GetMatchableAttributes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - project string
+//   - domain string
+//   - auth flyte.Auth
+func (_e *Client_Expecter) GetMatchableAttributes(ctx interface{}, project interface{}, domain interface{}, auth interface{}) *Client_GetMatchableAttributes_Call {
+	return &Client_GetMatchableAttributes_Call{Call: _e.mock.On("GetMatchableAttributes", ctx, project, domain, auth)}
+}
+
+func (_c *Client_GetMatchableAttributes_Call) Run(run func(ctx context.Context, project string, domain string, auth flyte.Auth)) *Client_GetMatchableAttributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_GetMatchableAttributes_Call) Return(_a0 flyte.MatchableAttributes, _a1 error) *Client_GetMatchableAttributes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetMatchableAttributes_Call) RunAndReturn(run func(context.Context, string, string, flyte.Auth) (flyte.MatchableAttributes, error)) *Client_GetMatchableAttributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProject provides a mock function with given fields: ctx, id, auth
+func (_m *Client) GetProject(ctx context.Context, id string, auth flyte.Auth) (flyte.Project, error) {
+	ret := _m.Called(ctx, id, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProject")
+	}
+
+	var r0 flyte.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, flyte.Auth) (flyte.Project, error)); ok {
+		return rf(ctx, id, auth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, flyte.Auth) flyte.Project); ok {
+		r0 = rf(ctx, id, auth)
+	} else {
+		r0 = ret.Get(0).(flyte.Project)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, flyte.Auth) error); ok {
+		r1 = rf(ctx, id, auth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProject'
+type Client_GetProject_Call struct {
+	*mock.Call
+}
+
+// GetProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - auth flyte.Auth
+func (_e *Client_Expecter) GetProject(ctx interface{}, id interface{}, auth interface{}) *Client_GetProject_Call {
+	return &Client_GetProject_Call{Call: _e.mock.On("GetProject", ctx, id, auth)}
+}
+
+func (_c *Client_GetProject_Call) Run(run func(ctx context.Context, id string, auth flyte.Auth)) *Client_GetProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_GetProject_Call) Return(_a0 flyte.Project, _a1 error) *Client_GetProject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetProject_Call) RunAndReturn(run func(context.Context, string, flyte.Auth) (flyte.Project, error)) *Client_GetProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterWorkflow provides a mock function with given fields: ctx, tgzPath, meta, auth
+func (_m *Client) RegisterWorkflow(ctx context.Context, tgzPath string, meta flyte.WorkflowMetadata, auth flyte.Auth) ([]flyte.RegistrationResult, error) {
+	ret := _m.Called(ctx, tgzPath, meta, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterWorkflow")
+	}
+
+	var r0 []flyte.RegistrationResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, flyte.WorkflowMetadata, flyte.Auth) ([]flyte.RegistrationResult, error)); ok {
+		return rf(ctx, tgzPath, meta, auth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, flyte.WorkflowMetadata, flyte.Auth) []flyte.RegistrationResult); ok {
+		r0 = rf(ctx, tgzPath, meta, auth)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flyte.RegistrationResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, flyte.WorkflowMetadata, flyte.Auth) error); ok {
+		r1 = rf(ctx, tgzPath, meta, auth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Client_RegisterWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterWorkflow'
 type Client_RegisterWorkflow_Call struct {
 	*mock.Call
@@ -61,12 +602,158 @@ func (_c *Client_RegisterWorkflow_Call) Run(run func(ctx context.Context, tgzPat
 	return _c
 }
 
-func (_c *Client_RegisterWorkflow_Call) Return(_a0 error) *Client_RegisterWorkflow_Call {
+func (_c *Client_RegisterWorkflow_Call) Return(_a0 []flyte.RegistrationResult, _a1 error) *Client_RegisterWorkflow_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_RegisterWorkflow_Call) RunAndReturn(run func(context.Context, string, flyte.WorkflowMetadata, flyte.Auth) ([]flyte.RegistrationResult, error)) *Client_RegisterWorkflow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TerminateExecution provides a mock function with given fields: ctx, project, domain, name, auth
+func (_m *Client) TerminateExecution(ctx context.Context, project string, domain string, name string, auth flyte.Auth) error {
+	ret := _m.Called(ctx, project, domain, name, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TerminateExecution")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, flyte.Auth) error); ok {
+		r0 = rf(ctx, project, domain, name, auth)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_TerminateExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TerminateExecution'
+type Client_TerminateExecution_Call struct {
+	*mock.Call
+}
+
+// TerminateExecution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - project string
+//   - domain string
+//   - name string
+//   - auth flyte.Auth
+func (_e *Client_Expecter) TerminateExecution(ctx interface{}, project interface{}, domain interface{}, name interface{}, auth interface{}) *Client_TerminateExecution_Call {
+	return &Client_TerminateExecution_Call{Call: _e.mock.On("TerminateExecution", ctx, project, domain, name, auth)}
+}
+
+func (_c *Client_TerminateExecution_Call) Run(run func(ctx context.Context, project string, domain string, name string, auth flyte.Auth)) *Client_TerminateExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_TerminateExecution_Call) Return(_a0 error) *Client_TerminateExecution_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_TerminateExecution_Call) RunAndReturn(run func(context.Context, string, string, string, flyte.Auth) error) *Client_TerminateExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMatchableAttributes provides a mock function with given fields: ctx, attributes, auth
+func (_m *Client) UpdateMatchableAttributes(ctx context.Context, attributes flyte.MatchableAttributes, auth flyte.Auth) error {
+	ret := _m.Called(ctx, attributes, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMatchableAttributes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, flyte.MatchableAttributes, flyte.Auth) error); ok {
+		r0 = rf(ctx, attributes, auth)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_UpdateMatchableAttributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMatchableAttributes'
+type Client_UpdateMatchableAttributes_Call struct {
+	*mock.Call
+}
+
+// UpdateMatchableAttributes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - attributes flyte.MatchableAttributes
+//   - auth flyte.Auth
+func (_e *Client_Expecter) UpdateMatchableAttributes(ctx interface{}, attributes interface{}, auth interface{}) *Client_UpdateMatchableAttributes_Call {
+	return &Client_UpdateMatchableAttributes_Call{Call: _e.mock.On("UpdateMatchableAttributes", ctx, attributes, auth)}
+}
+
+func (_c *Client_UpdateMatchableAttributes_Call) Run(run func(ctx context.Context, attributes flyte.MatchableAttributes, auth flyte.Auth)) *Client_UpdateMatchableAttributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(flyte.MatchableAttributes), args[2].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_UpdateMatchableAttributes_Call) Return(_a0 error) *Client_UpdateMatchableAttributes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_UpdateMatchableAttributes_Call) RunAndReturn(run func(context.Context, flyte.MatchableAttributes, flyte.Auth) error) *Client_UpdateMatchableAttributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProject provides a mock function with given fields: ctx, project, auth
+func (_m *Client) UpdateProject(ctx context.Context, project flyte.Project, auth flyte.Auth) error {
+	ret := _m.Called(ctx, project, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, flyte.Project, flyte.Auth) error); ok {
+		r0 = rf(ctx, project, auth)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_UpdateProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProject'
+type Client_UpdateProject_Call struct {
+	*mock.Call
+}
+
+// UpdateProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - project flyte.Project
+//   - auth flyte.Auth
+func (_e *Client_Expecter) UpdateProject(ctx interface{}, project interface{}, auth interface{}) *Client_UpdateProject_Call {
+	return &Client_UpdateProject_Call{Call: _e.mock.On("UpdateProject", ctx, project, auth)}
+}
+
+func (_c *Client_UpdateProject_Call) Run(run func(ctx context.Context, project flyte.Project, auth flyte.Auth)) *Client_UpdateProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(flyte.Project), args[2].(flyte.Auth))
+	})
+	return _c
+}
+
+func (_c *Client_UpdateProject_Call) Return(_a0 error) *Client_UpdateProject_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Client_RegisterWorkflow_Call) RunAndReturn(run func(context.Context, string, flyte.WorkflowMetadata, flyte.Auth) error) *Client_RegisterWorkflow_Call {
+func (_c *Client_UpdateProject_Call) RunAndReturn(run func(context.Context, flyte.Project, flyte.Auth) error) *Client_UpdateProject_Call {
 	_c.Call.Return(run)
 	return _c
 }
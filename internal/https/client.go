@@ -0,0 +1,313 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package https contains a struct used for downloading artifacts from HTTPS servers, such as GitHub release assets or
+// Nexus raw repositories
+package https
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/digest"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/signature"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// maxAttempts is the number of times a download is attempted, each attempt resumes from the downloaded bytes
+const maxAttempts = 3
+
+// maxChecksumSize caps the size of the `.sha256` sidecar file
+const maxChecksumSize = 1024
+
+// Downloader is a struct that exposes a function for downloading an artifact from an HTTPS server.
+type Downloader struct {
+	cfg    internal.Config
+	client *http.Client
+}
+
+// NewDownloader returns a downloader from a given config. The certificates of the configured CA bundle are trusted
+// besides the system roots.
+func NewDownloader(cfg internal.Config) (*Downloader, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.HTTPSCABundle != "" {
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+
+		bundle, err := os.ReadFile(cfg.HTTPSCABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+
+		if !roots.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", cfg.HTTPSCABundle)
+		}
+
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	}
+
+	return &Downloader{
+		cfg: cfg,
+		// Redirects are followed, the Authorization header is only sent on to the same host and its subdomains
+		client: &http.Client{Transport: transport},
+	}, nil
+}
+
+// PackageURL returns the URL of a package URI without its scheme, e.g.
+// `github.com/adarga-ai/workflows/releases/download/v{version}/package.tgz`. The `{version}` placeholder is replaced
+// with the version, the paths without it get the `_<version>.tgz` suffix JFrog packages are stored with.
+func PackageURL(uri string, version string) (*url.URL, error) {
+	u, err := url.Parse("https://" + uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTPS URI %s: %w", uri, err)
+	}
+
+	if u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return nil, fmt.Errorf("invalid HTTPS URI %s, expected host/path", uri)
+	}
+
+	if strings.Contains(uri, internal.VersionPlaceholder) {
+		u.Path = strings.ReplaceAll(u.Path, internal.VersionPlaceholder, version)
+		u.RawQuery = strings.ReplaceAll(u.RawQuery, internal.VersionPlaceholder, url.QueryEscape(version))
+		u.RawPath = ""
+	} else {
+		u.Path = fmt.Sprintf("%s_%s.tgz", strings.TrimSuffix(u.Path, "/"), version)
+	}
+
+	return u, nil
+}
+
+// DownloadArtifact downloads an artifact from an HTTPS server into the workspace of the request.
+// The uri is the URL of the artifact without its scheme, see PackageURL.
+// The credentials, when given, authenticate with a bearer token, or with basic auth when they have no token
+// The size of each response is reserved in the workspace before it is written when the server reports it, and once
+// it has been written otherwise, so downloads over the cap are refused
+// A download that is cut off is resumed with a range request, as long as the server reports a strong ETag for the
+// file
+// The artifact resolves to the sha256 digest of the downloaded file. It is verified against the `.sha256` sidecar
+// file next to the artifact when the server has one, which is required when HTTPSRequireChecksum is set.
+// Signatures are only supported for OCI artifacts, so artifacts that must be signed are refused.
+func (d *Downloader) DownloadArtifact(ctx context.Context, req internal.DownloadRequest) (internal.Artifact, error) {
+	if req.VerifySignature {
+		return internal.Artifact{}, fmt.Errorf("%w: signatures can only be verified for oci packages", signature.ErrUnverified)
+	}
+
+	u, err := PackageURL(req.URI, req.Version)
+	if err != nil {
+		return internal.Artifact{}, err
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("downloading artifact...",
+		"url", u.Redacted(),
+	)
+
+	artifactPath := filepath.Join(req.Workspace.Dir, path.Base(u.Path))
+	etag, err := d.download(ctx, u, req, artifactPath)
+	if err != nil {
+		return internal.Artifact{}, err
+	}
+
+	fileDigest, err := digest.File(artifactPath)
+	if err != nil {
+		return internal.Artifact{}, err
+	}
+
+	checksum, err := d.checksum(ctx, u, req.Credentials)
+	if err != nil {
+		return internal.Artifact{}, err
+	}
+
+	switch {
+	case checksum != "":
+		if err := digest.Verify(checksum, fileDigest); err != nil {
+			return internal.Artifact{}, fmt.Errorf("artifact does not match its .sha256 file: %w", err)
+		}
+	case d.cfg.HTTPSRequireChecksum:
+		return internal.Artifact{}, fmt.Errorf("no .sha256 file found for %s", u.Redacted())
+	}
+
+	if err := digest.Verify(req.Digest, fileDigest); err != nil {
+		return internal.Artifact{}, err
+	}
+
+	return internal.Artifact{Path: artifactPath, Digest: fileDigest, ETag: etag}, nil
+}
+
+// ListVersions is not supported, the versions of a package URI with a `{version}` placeholder cannot be listed from
+// HTTPS servers, so version policies can only be used with the OCI and JFrog strategies.
+func (d *Downloader) ListVersions(_ context.Context, _ internal.VersionsRequest) ([]string, error) {
+	return nil, fmt.Errorf("%w for https packages", internal.ErrVersionsUnsupported)
+}
+
+// download downloads the URL to a file and returns its ETag. When the connection is lost, before or during the
+// response, the download is resumed from the bytes already written, and started over when the server does not resume
+// it or the file has changed.
+func (d *Downloader) download(ctx context.Context, u *url.URL, req internal.DownloadRequest, artifactPath string) (string, error) {
+	f, err := os.Create(artifactPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	// reserved is the size of the file reserved in the workspace so far, a resumed download only reserves the rest
+	var offset, reserved int64
+	var etag string
+	for attempt := 1; ; attempt++ {
+		resp, err := d.get(ctx, u, req.Credentials, offset, etag)
+		if err != nil {
+			// The connection was lost before the response, the next attempt resumes from the same offset
+			if ctx.Err() != nil || attempt == maxAttempts {
+				return "", fmt.Errorf("failed to download %s after %d attempts: %w", u.Redacted(), attempt, err)
+			}
+			log.FromContext(ctx).Info("retrying download", "url", u.Redacted(), "offset", offset, "error", err.Error())
+			continue
+		}
+
+		switch {
+		case offset > 0 && resp.StatusCode == http.StatusPartialContent:
+			log.FromContext(ctx).Info("resuming download", "url", u.Redacted(), "offset", offset)
+		case resp.StatusCode == http.StatusOK:
+			if err := restart(f); err != nil {
+				resp.Body.Close()
+				return "", err
+			}
+			offset = 0
+			etag = resp.Header.Get("ETag")
+		default:
+			resp.Body.Close()
+			return "", fmt.Errorf("failed to download %s: %s", u.Redacted(), resp.Status)
+		}
+
+		if resp.ContentLength >= 0 && offset+resp.ContentLength > reserved {
+			if err := req.Workspace.Reserve(offset + resp.ContentLength - reserved); err != nil {
+				resp.Body.Close()
+				return "", err
+			}
+			reserved = offset + resp.ContentLength
+		}
+
+		n, err := io.Copy(f, resp.Body)
+		resp.Body.Close()
+		offset += n
+
+		if err == nil {
+			if offset > reserved {
+				if err := req.Workspace.Reserve(offset - reserved); err != nil {
+					return "", err
+				}
+			}
+			return strings.Trim(etag, `"`), f.Close()
+		}
+
+		// Only a download of a file with a strong ETag can be resumed, otherwise it is started over
+		if etag == "" || strings.HasPrefix(etag, "W/") {
+			offset = 0
+		}
+
+		if ctx.Err() != nil || attempt == maxAttempts {
+			return "", fmt.Errorf("failed to download %s after %d attempts: %w", u.Redacted(), attempt, err)
+		}
+	}
+}
+
+// get requests the URL, from the offset of a file with the ETag when the offset is not 0
+func (d *Downloader) get(ctx context.Context, u *url.URL, creds *internal.Credentials, offset int64, etag string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", etag)
+	}
+
+	if creds != nil {
+		if creds.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+creds.Token)
+		} else {
+			req.SetBasicAuth(creds.Username, creds.Password)
+		}
+	}
+
+	return d.client.Do(req)
+}
+
+// checksum returns the digest in the `.sha256` sidecar file of the URL, or an empty digest when there is none. The
+// file holds the hex sha256 of the artifact, optionally followed by its name as written by sha256sum.
+func (d *Downloader) checksum(ctx context.Context, u *url.URL, creds *internal.Credentials) (string, error) {
+	sidecar := *u
+	sidecar.Path += ".sha256"
+	sidecar.RawPath = ""
+
+	resp, err := d.get(ctx, &sidecar, creds, 0, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", sidecar.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download %s: %s", sidecar.Redacted(), resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxChecksumSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", sidecar.Redacted(), err)
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("invalid checksum in %s", sidecar.Redacted())
+	}
+
+	sum := strings.ToLower(fields[0])
+	if decoded, err := hex.DecodeString(sum); err != nil || len(decoded) != 32 {
+		return "", fmt.Errorf("invalid checksum in %s", sidecar.Redacted())
+	}
+
+	return "sha256:" + sum, nil
+}
+
+// restart empties a partially written file, so the download can be started over
+func restart(f *os.File) error {
+	if err := f.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate file: %w", err)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to truncate file: %w", err)
+	}
+
+	return nil
+}
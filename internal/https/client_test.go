@@ -0,0 +1,405 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package https
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/digest"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/signature"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packageSum is the sha256 of "package"
+const packageSum = "bc4a71180870f7945155fbb02f4b0a2e3faa2a62d6d31b7039013055ed19869a"
+
+// server is a stand-in for an HTTPS server hosting packages
+type server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// files are the files served by path
+	files map[string]string
+	// authorization is the authorization header of the last request
+	authorization string
+	// ranges are the range headers of the requests
+	ranges []string
+	// failures is the number of downloads of a package cut off before they complete
+	failures int
+	// drops is the number of downloads of a package whose connection is closed before the response, once the downloads
+	// that are cut off have failed
+	drops int
+}
+
+// newServer starts a TLS server serving the files, with a `/redirect/` prefix redirecting to the file
+func newServer(t *testing.T, files map[string]string) *server {
+	t.Helper()
+	s := &server{files: files}
+
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.authorization = r.Header.Get("Authorization")
+		s.ranges = append(s.ranges, r.Header.Get("Range"))
+		fail := s.failures > 0 && !strings.HasSuffix(r.URL.Path, ".sha256")
+		if fail {
+			s.failures--
+		}
+		drop := !fail && s.drops > 0 && !strings.HasSuffix(r.URL.Path, ".sha256")
+		if drop {
+			s.drops--
+		}
+		s.mu.Unlock()
+
+		if drop {
+			// Close the connection without a response, the client sees a transport error
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				_ = conn.Close()
+			}
+			return
+		}
+
+		if target, ok := strings.CutPrefix(r.URL.Path, "/redirect"); ok {
+			http.Redirect(w, r, target, http.StatusFound)
+			return
+		}
+
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("ETag", `"test-etag"`)
+
+		offset := 0
+		if rng, ok := strings.CutPrefix(r.Header.Get("Range"), "bytes="); ok && r.Header.Get("If-Range") == `"test-etag"` {
+			offset, _ = strconv.Atoi(strings.TrimSuffix(rng, "-"))
+			w.Header().Set("Content-Range", "bytes "+strconv.Itoa(offset)+"-"+strconv.Itoa(len(body)-1)+"/"+strconv.Itoa(len(body)))
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)-offset))
+			w.WriteHeader(http.StatusPartialContent)
+		} else {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+
+		if fail {
+			// Cut the connection off half way through the body
+			_, _ = w.Write([]byte(body[offset : offset+(len(body)-offset)/2]))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+
+		_, _ = w.Write([]byte(body[offset:]))
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// host returns the host of the server, package URIs have no scheme
+func (s *server) host() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+// newDownloader returns a downloader trusting the certificate of the server
+func newDownloader(t *testing.T, s *server, cfg internal.Config) *Downloader {
+	t.Helper()
+
+	bundle := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}), 0o600))
+	cfg.HTTPSCABundle = bundle
+
+	d, err := NewDownloader(cfg)
+	require.NoError(t, err)
+	return d
+}
+
+func TestDownloadArtifact(t *testing.T) {
+	s := newServer(t, map[string]string{
+		"/packages/data-warehouse_1.2.3.tgz":  "package",
+		"/releases/v1.2.3/package.tgz":        "package",
+		"/releases/v1.2.3/package.tgz.sha256": packageSum + "  package.tgz\n",
+		"/releases/v1.2.4/package.tgz":        "corrupted",
+		"/releases/v1.2.4/package.tgz.sha256": packageSum + "  package.tgz\n",
+		"/large/v1.2.3/package.tgz":           strings.Repeat("package", 1000),
+		"/unverified/v1.2.3/package.tgz":      "package",
+	})
+	d := newDownloader(t, s, internal.Config{})
+	workspaces := workspace.NewManager(t.TempDir(), 0)
+
+	t.Run("we can download a package successfully", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		artifact, err := d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: s.host() + "/packages/data-warehouse", Version: "1.2.3", Workspace: ws})
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(ws.Dir, "data-warehouse_1.2.3.tgz"), artifact.Path)
+		assert.Equal(t, "sha256:"+packageSum, artifact.Digest)
+		assert.Equal(t, "test-etag", artifact.ETag)
+
+		data, err := os.ReadFile(artifact.Path)
+		require.NoError(t, err)
+		assert.Equal(t, "package", string(data))
+	})
+
+	t.Run("the version is templated into the URL and the package is verified against its checksum", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		artifact, err := d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: s.host() + "/releases/v{version}/package.tgz", Version: "1.2.3", Workspace: ws})
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(ws.Dir, "package.tgz"), artifact.Path)
+		assert.Equal(t, "sha256:"+packageSum, artifact.Digest)
+	})
+
+	t.Run("packages that do not match their checksum are refused", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: s.host() + "/releases/v{version}/package.tgz", Version: "1.2.4", Workspace: ws})
+
+		assert.ErrorIs(t, err, digest.ErrMismatch)
+		assert.ErrorContains(t, err, "artifact does not match its .sha256 file")
+	})
+
+	t.Run("packages without a checksum are refused when checksums are required", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = newDownloader(t, s, internal.Config{HTTPSRequireChecksum: true}).DownloadArtifact(context.Background(), internal.DownloadRequest{
+			URI:       s.host() + "/unverified/v{version}/package.tgz",
+			Version:   "1.2.3",
+			Workspace: ws,
+		})
+
+		assert.ErrorContains(t, err, "no .sha256 file found")
+	})
+
+	t.Run("basic auth is sent from the credentials", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{
+			URI:         s.host() + "/packages/data-warehouse",
+			Version:     "1.2.3",
+			Workspace:   ws,
+			Credentials: &internal.Credentials{Username: "user", Password: "pass"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Basic dXNlcjpwYXNz", s.authorization)
+	})
+
+	t.Run("a bearer token is sent from the credentials", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{
+			URI:         s.host() + "/packages/data-warehouse",
+			Version:     "1.2.3",
+			Workspace:   ws,
+			Credentials: &internal.Credentials{Token: "token"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token", s.authorization)
+	})
+
+	t.Run("redirects are followed", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		artifact, err := d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: s.host() + "/redirect/packages/data-warehouse", Version: "1.2.3", Workspace: ws})
+
+		require.NoError(t, err)
+		assert.Equal(t, "sha256:"+packageSum, artifact.Digest)
+	})
+
+	t.Run("downloads that are cut off are resumed", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+		s.mu.Lock()
+		s.failures = 2
+		s.ranges = nil
+		s.mu.Unlock()
+
+		artifact, err := d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: s.host() + "/large/v{version}/package.tgz", Version: "1.2.3", Workspace: ws})
+
+		require.NoError(t, err)
+		data, err := os.ReadFile(artifact.Path)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("package", 1000), string(data))
+		assert.Equal(t, []string{"", "bytes=3500-", "bytes=5250-", ""}, s.ranges)
+	})
+
+	t.Run("downloads whose connection is closed are retried and resumed", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+		s.mu.Lock()
+		s.failures = 1
+		s.drops = 1
+		s.ranges = nil
+		s.mu.Unlock()
+
+		// A new client does not reuse a connection, which the transport would retry on its own
+		artifact, err := newDownloader(t, s, internal.Config{}).DownloadArtifact(context.Background(), internal.DownloadRequest{
+			URI:       s.host() + "/large/v{version}/package.tgz",
+			Version:   "1.2.3",
+			Workspace: ws,
+		})
+
+		require.NoError(t, err)
+		data, err := os.ReadFile(artifact.Path)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("package", 1000), string(data))
+		assert.Equal(t, []string{"", "bytes=3500-", "bytes=3500-", ""}, s.ranges)
+	})
+
+	t.Run("downloads whose connection keeps being closed fail", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+		s.mu.Lock()
+		s.drops = maxAttempts
+		s.mu.Unlock()
+
+		_, err = newDownloader(t, s, internal.Config{}).DownloadArtifact(context.Background(), internal.DownloadRequest{
+			URI:       s.host() + "/large/v{version}/package.tgz",
+			Version:   "1.2.3",
+			Workspace: ws,
+		})
+
+		assert.ErrorContains(t, err, "after 3 attempts")
+	})
+
+	t.Run("downloads that keep being cut off fail", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+		s.mu.Lock()
+		s.failures = maxAttempts
+		s.mu.Unlock()
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: s.host() + "/large/v{version}/package.tgz", Version: "1.2.3", Workspace: ws})
+
+		assert.ErrorContains(t, err, "after 3 attempts")
+	})
+
+	t.Run("missing packages fail the download", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: s.host() + "/packages/missing", Version: "1.2.3", Workspace: ws})
+
+		assert.ErrorContains(t, err, "404 Not Found")
+	})
+
+	t.Run("servers that are not trusted are refused", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+		untrusted, err := NewDownloader(internal.Config{})
+		require.NoError(t, err)
+
+		_, err = untrusted.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: s.host() + "/packages/data-warehouse", Version: "1.2.3", Workspace: ws})
+
+		assert.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("packages without the pinned digest are refused", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{
+			URI:       s.host() + "/packages/data-warehouse",
+			Version:   "1.2.3",
+			Workspace: ws,
+			Digest:    "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		})
+
+		assert.ErrorIs(t, err, digest.ErrMismatch)
+	})
+
+	t.Run("downloads over the workspace cap are refused", func(t *testing.T) {
+		ws, err := workspace.NewManager(t.TempDir(), 4).Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: s.host() + "/packages/data-warehouse", Version: "1.2.3", Workspace: ws})
+
+		assert.ErrorIs(t, err, workspace.ErrCapExceeded)
+	})
+
+	t.Run("packages that must be signed are refused", func(t *testing.T) {
+		_, err := d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: s.host() + "/packages/data-warehouse", Version: "1.2.3", VerifySignature: true})
+
+		assert.ErrorIs(t, err, signature.ErrUnverified)
+	})
+}
+
+func TestPackageURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "paths without a placeholder get the version suffix",
+			uri:  "example.com/packages/data-warehouse",
+			want: "https://example.com/packages/data-warehouse_1.2.3.tgz",
+		},
+		{
+			name: "the placeholder is replaced with the version",
+			uri:  "github.com/adarga-ai/workflows/releases/download/v{version}/package-{version}.tgz",
+			want: "https://github.com/adarga-ai/workflows/releases/download/v1.2.3/package-1.2.3.tgz",
+		},
+		{
+			name: "the placeholder is replaced in the query",
+			uri:  "example.com/download?file=package.tgz&version={version}",
+			want: "https://example.com/download?file=package.tgz&version=1.2.3",
+		},
+		{
+			name:    "a host without a path is invalid",
+			uri:     "example.com",
+			wantErr: "invalid HTTPS URI example.com, expected host/path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PackageURL(tt.uri, "1.2.3")
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
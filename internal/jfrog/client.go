@@ -21,12 +21,17 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/digest"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/signature"
 	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/auth"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	"github.com/jfrog/jfrog-client-go/config"
 	"k8s.io/kube-openapi/pkg/validation/errors"
 )
@@ -37,44 +42,174 @@ type Downloader struct {
 	JFrogManager artifactory.ArtifactoryServicesManager
 }
 
-// DownloadArtifact downloads an artifact from JFrog
-func (d *Downloader) DownloadArtifact(_ context.Context, uri string, version string) (string, error) {
-	packagePath := fmt.Sprintf("%s_%s.tgz", uri, version)
+// DownloadArtifact downloads an artifact from JFrog into the workspace of the request. When credentials are given a
+// JFrog manager is set up for them instead of using the one set up with the configured credentials.
+// The artifact is looked up in Artifactory first, and its size is reserved in the workspace before anything is
+// downloaded, so downloads over the cap fail before they are written.
+// The artifact resolves to the sha256 checksum of the downloaded file, which must match the sha256 checksum Artifactory
+// reports for the artifact, so that a download corrupted on the way is refused.
+// Signatures are only supported for OCI artifacts, so artifacts that must be signed are refused.
+func (d *Downloader) DownloadArtifact(ctx context.Context, req internal.DownloadRequest) (internal.Artifact, error) {
+	if req.VerifySignature {
+		return internal.Artifact{}, fmt.Errorf("%w: signatures can only be verified for oci packages", signature.ErrUnverified)
+	}
+
+	manager := d.JFrogManager
+	if req.Credentials != nil {
+		var err error
+		manager, err = d.newManager(ctx, *req.Credentials)
+		if err != nil {
+			return internal.Artifact{}, fmt.Errorf("jfrog manager: setting up with credentials: %w", err)
+		}
+	}
+
+	packagePath := fmt.Sprintf("%s_%s.tgz", req.URI, req.Version)
+
+	item, err := findFile(manager, packagePath)
+	if err != nil {
+		return internal.Artifact{}, err
+	}
+
+	if err := req.Workspace.Reserve(item.Size); err != nil {
+		return internal.Artifact{}, err
+	}
 
 	params := services.NewDownloadParams()
 
 	// The full path to the artifact in Artifactory, including the repository name
 	params.Pattern = packagePath
 
-	// Dynamically determine the folder name from the packagePath
-	folderPath := filepath.Dir(packagePath) // This extracts the folder path from the full package path
-	folderName := filepath.Base(folderPath) // This gets the actual folder name
-
-	// The local file system path where the downloaded files will be saved
-	params.Target = filepath.Join(os.TempDir(), filepath.Base(packagePath))
+	// The local directory the downloaded file is saved to, without the folders of the package path
+	params.Target = req.Workspace.Dir + string(filepath.Separator)
+	params.Flat = true
 
 	params.SplitCount = 2
 
 	params.MinSplitSize = 7168
 
-	totalDownloaded, _, err := d.JFrogManager.DownloadFiles(params)
+	totalDownloaded, _, err := manager.DownloadFiles(params)
 	// Handler errors
 	if err != nil {
-		return "", fmt.Errorf("jfrog manager: downloading files: %w", err)
+		return internal.Artifact{}, fmt.Errorf("jfrog manager: downloading files: %w", err)
 	}
 	if totalDownloaded < 1 {
-		return "", errors.New(1, fmt.Sprintf("no files to download: %s", packagePath))
+		return internal.Artifact{}, errors.New(1, fmt.Sprintf("no files to download: %s", packagePath))
+	}
+
+	artifactPath := filepath.Join(req.Workspace.Dir, filepath.Base(packagePath))
+	info, err := os.Stat(artifactPath)
+	if err != nil {
+		return internal.Artifact{}, fmt.Errorf("reading downloaded file: %w", err)
+	}
+
+	// The file may have been replaced in Artifactory since it was looked up
+	if info.Size() > item.Size {
+		if err := req.Workspace.Reserve(info.Size() - item.Size); err != nil {
+			return internal.Artifact{}, err
+		}
+	}
+
+	checksum, err := digest.File(artifactPath)
+	if err != nil {
+		return internal.Artifact{}, err
+	}
+
+	// Artifacts stored before Artifactory calculated sha256 checksums may not have one
+	if item.Sha256 != "" {
+		if err := digest.Verify("sha256:"+strings.ToLower(item.Sha256), checksum); err != nil {
+			return internal.Artifact{}, fmt.Errorf("artifact does not match its sha256 checksum in artifactory: %w", err)
+		}
+	}
+
+	if err := digest.Verify(req.Digest, checksum); err != nil {
+		return internal.Artifact{}, err
+	}
+
+	return internal.Artifact{Path: artifactPath, Digest: checksum}, nil
+}
+
+// ListVersions lists the versions of the workflow package in Artifactory, from the names of the
+// `<uri>_<version>.tgz` files next to each other in its repository path.
+func (d *Downloader) ListVersions(ctx context.Context, req internal.VersionsRequest) ([]string, error) {
+	manager := d.JFrogManager
+	if req.Credentials != nil {
+		var err error
+		manager, err = d.newManager(ctx, *req.Credentials)
+		if err != nil {
+			return nil, fmt.Errorf("jfrog manager: setting up with credentials: %w", err)
+		}
+	}
+
+	params := services.NewSearchParams()
+	params.Pattern = req.URI + "_*.tgz"
+	params.Recursive = false
+
+	reader, err := manager.SearchFiles(params)
+	if err != nil {
+		return nil, fmt.Errorf("jfrog manager: searching files: %w", err)
+	}
+	defer reader.Close()
+
+	prefix := path.Base(req.URI) + "_"
+	var versions []string
+	for item := new(utils.ResultItem); reader.NextRecord(item) == nil; item = new(utils.ResultItem) {
+		if version, ok := strings.CutPrefix(strings.TrimSuffix(item.Name, ".tgz"), prefix); ok {
+			versions = append(versions, version)
+		}
+	}
+	if err := reader.GetError(); err != nil {
+		return nil, fmt.Errorf("jfrog manager: reading search results: %w", err)
+	}
+
+	return versions, nil
+}
+
+// findFile looks up a file in Artifactory by its full path, including the repository name, and returns its details
+func findFile(manager artifactory.ArtifactoryServicesManager, filePath string) (utils.ResultItem, error) {
+	params := services.NewSearchParams()
+	params.Pattern = filePath
+	params.Recursive = false
+
+	reader, err := manager.SearchFiles(params)
+	if err != nil {
+		return utils.ResultItem{}, fmt.Errorf("jfrog manager: searching files: %w", err)
+	}
+	defer reader.Close()
+
+	var item utils.ResultItem
+	if err := reader.NextRecord(&item); err != nil {
+		if err := reader.GetError(); err != nil {
+			return utils.ResultItem{}, fmt.Errorf("jfrog manager: reading search results: %w", err)
+		}
+		return utils.ResultItem{}, errors.New(1, fmt.Sprintf("no files to download: %s", filePath))
 	}
 
-	return filepath.Join(os.TempDir(), folderName, filepath.Base(packagePath)), nil
+	return item, nil
 }
 
 // SetupDownloader sets up the JFrog downloader
 func (d *Downloader) SetupDownloader(ctx context.Context) error {
+	rtManager, err := d.newManager(ctx, internal.Credentials{Username: d.Config.JfrogUser, Password: d.Config.JfrogPassword})
+	if err != nil {
+		return err
+	}
+
+	d.JFrogManager = rtManager
+
+	return nil
+}
+
+// newManager sets up a JFrog manager for the configured artifactory with the given credentials. A token is used as
+// the access token of Artifactory instead of the username and password.
+func (d *Downloader) newManager(ctx context.Context, credentials internal.Credentials) (artifactory.ArtifactoryServicesManager, error) {
 	rtDetails := auth.NewArtifactoryDetails()
 	rtDetails.SetUrl(d.Config.JfrogURL)
-	rtDetails.SetUser(d.Config.JfrogUser)
-	rtDetails.SetPassword(d.Config.JfrogPassword)
+	if credentials.Token != "" {
+		rtDetails.SetAccessToken(credentials.Token)
+	} else {
+		rtDetails.SetUser(credentials.Username)
+		rtDetails.SetPassword(credentials.Password)
+	}
 
 	serviceConfig, err := config.NewConfigBuilder().
 		SetServiceDetails(rtDetails).
@@ -83,15 +218,8 @@ func (d *Downloader) SetupDownloader(ctx context.Context) error {
 		Build()
 	if err != nil {
 		// Handle error
-		return errors.New(1, "error creating service config")
+		return nil, errors.New(1, "error creating service config")
 	}
 
-	rtManager, err := artifactory.New(serviceConfig)
-	if err != nil {
-		return err
-	}
-
-	d.JFrogManager = rtManager
-
-	return nil
+	return artifactory.New(serviceConfig)
 }
@@ -18,36 +18,214 @@ package jfrog
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/digest"
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/jfrog/mocks"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/signature"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/workspace"
 
 	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Used to generate tests for the ArtifactDownloader interface
 //go:generate mockery --srcpkg=github.com/jfrog/jfrog-client-go/artifactory --name=ArtifactoryServicesManager
 
+// searchResults returns a reader of search results as Artifactory returns them, in a file
+func searchResults(t *testing.T, results string) *content.ContentReader {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"results": [`+results+`]}`), 0o600))
+
+	return content.NewContentReader(path, content.DefaultKey)
+}
+
+// packageResult is the search result of the package, whose content is "package"
+const packageResult = `{"repo": "repo", "path": ".", "name": "packagePath_1.2.3.tgz", "size": 7,
+	"sha256": "bc4a71180870f7945155fbb02f4b0a2e3faa2a62d6d31b7039013055ed19869a"}`
+
 func TestDownloadArtifact(t *testing.T) {
 	t.Run("we can download a file successfully", func(t *testing.T) {
 		// Set up the mocks
 		jFrogManagerMock := mocks.NewArtifactoryServicesManager(t)
 		numDownloaded := 1
 		numFailed := 0
-		jFrogManagerMock.On("DownloadFiles", mock.Anything).Return(numDownloaded, numFailed, nil)
+		ws, err := workspace.NewManager(t.TempDir(), 0).Create("test")
+		assert.NoError(t, err)
+
+		// Artifactory reports the package, then writes it into the workspace
+		jFrogManagerMock.On("SearchFiles", mock.Anything).
+			Run(func(args mock.Arguments) {
+				params := args.Get(0).(services.SearchParams)
+				assert.Equal(t, "repo/packagePath_1.2.3.tgz", params.Pattern)
+			}).Return(searchResults(t, packageResult), nil)
+		jFrogManagerMock.On("DownloadFiles", mock.Anything).
+			Run(func(args mock.Arguments) {
+				params := args.Get(0).(services.DownloadParams)
+				assert.Equal(t, "repo/packagePath_1.2.3.tgz", params.Pattern)
+				assert.NoError(t, os.WriteFile(filepath.Join(params.Target, "packagePath_1.2.3.tgz"), []byte("package"), 0o600))
+			}).Return(numDownloaded, numFailed, nil)
 		j := Downloader{
 			JFrogManager: jFrogManagerMock,
 		}
 
 		// Call the method we are testing
-		result, err := j.DownloadArtifact(context.Background(), "packagePath", "1.2.3")
+		result, err := j.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "repo/packagePath", Version: "1.2.3", Workspace: ws})
 		assert.NoError(t, err)
 
 		// Assert the result
-		assert.Contains(t, result, "packagePath")
+		assert.Equal(t, filepath.Join(ws.Dir, "packagePath_1.2.3.tgz"), result.Path)
+		assert.Equal(t, "sha256:bc4a71180870f7945155fbb02f4b0a2e3faa2a62d6d31b7039013055ed19869a", result.Digest)
+	})
+
+	t.Run("downloads over the workspace cap are refused before they are written", func(t *testing.T) {
+		// The mock does not expect a download
+		jFrogManagerMock := mocks.NewArtifactoryServicesManager(t)
+		ws, err := workspace.NewManager(t.TempDir(), 4).Create("test")
+		assert.NoError(t, err)
+
+		jFrogManagerMock.On("SearchFiles", mock.Anything).Return(searchResults(t, packageResult), nil)
+		j := Downloader{
+			JFrogManager: jFrogManagerMock,
+		}
+
+		_, err = j.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "repo/packagePath", Version: "1.2.3", Workspace: ws})
+		assert.ErrorIs(t, err, workspace.ErrCapExceeded)
+
+		entries, err := os.ReadDir(ws.Dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("packages missing from artifactory are not downloaded", func(t *testing.T) {
+		jFrogManagerMock := mocks.NewArtifactoryServicesManager(t)
+		ws, err := workspace.NewManager(t.TempDir(), 0).Create("test")
+		assert.NoError(t, err)
+
+		jFrogManagerMock.On("SearchFiles", mock.Anything).Return(searchResults(t, ""), nil)
+		j := Downloader{
+			JFrogManager: jFrogManagerMock,
+		}
+
+		_, err = j.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "repo/packagePath", Version: "1.2.3", Workspace: ws})
+		assert.ErrorContains(t, err, "no files to download: repo/packagePath_1.2.3.tgz")
+	})
+
+	t.Run("packages that must be signed are refused", func(t *testing.T) {
+		j := Downloader{
+			JFrogManager: mocks.NewArtifactoryServicesManager(t),
+		}
+
+		_, err := j.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "repo/packagePath", Version: "1.2.3", VerifySignature: true})
+		assert.ErrorIs(t, err, signature.ErrUnverified)
+	})
+
+	t.Run("packages that do not match their checksum in artifactory are refused", func(t *testing.T) {
+		jFrogManagerMock := mocks.NewArtifactoryServicesManager(t)
+		ws, err := workspace.NewManager(t.TempDir(), 0).Create("test")
+		assert.NoError(t, err)
+
+		// The download is corrupted on the way
+		jFrogManagerMock.On("SearchFiles", mock.Anything).Return(searchResults(t, packageResult), nil)
+		jFrogManagerMock.On("DownloadFiles", mock.Anything).
+			Run(func(args mock.Arguments) {
+				params := args.Get(0).(services.DownloadParams)
+				assert.NoError(t, os.WriteFile(filepath.Join(params.Target, "packagePath_1.2.3.tgz"), []byte("pakcage"), 0o600))
+			}).Return(1, 0, nil)
+		j := Downloader{
+			JFrogManager: jFrogManagerMock,
+		}
+
+		_, err = j.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "repo/packagePath", Version: "1.2.3", Workspace: ws})
+		assert.ErrorIs(t, err, digest.ErrMismatch)
+		assert.ErrorContains(t, err, "artifact does not match its sha256 checksum in artifactory")
+	})
+
+	t.Run("packages without the pinned digest are refused", func(t *testing.T) {
+		jFrogManagerMock := mocks.NewArtifactoryServicesManager(t)
+		ws, err := workspace.NewManager(t.TempDir(), 0).Create("test")
+		assert.NoError(t, err)
+
+		jFrogManagerMock.On("SearchFiles", mock.Anything).Return(searchResults(t, packageResult), nil)
+
+		jFrogManagerMock.On("DownloadFiles", mock.Anything).
+			Run(func(args mock.Arguments) {
+				params := args.Get(0).(services.DownloadParams)
+				assert.NoError(t, os.WriteFile(filepath.Join(params.Target, "packagePath_1.2.3.tgz"), []byte("package"), 0o600))
+			}).Return(1, 0, nil)
+		j := Downloader{
+			JFrogManager: jFrogManagerMock,
+		}
+
+		_, err = j.DownloadArtifact(context.Background(), internal.DownloadRequest{
+			URI:       "repo/packagePath",
+			Version:   "1.2.3",
+			Workspace: ws,
+			Digest:    "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		})
+		assert.ErrorIs(t, err, digest.ErrMismatch)
+	})
+}
+
+func TestListVersions(t *testing.T) {
+	t.Run("we can list the versions of a package successfully", func(t *testing.T) {
+		jFrogManagerMock := mocks.NewArtifactoryServicesManager(t)
+
+		// Artifactory returns the search results in a file
+		results := filepath.Join(t.TempDir(), "results.json")
+		assert.NoError(t, os.WriteFile(results, []byte(`{"results": [
+			{"repo": "repo", "path": ".", "name": "packagePath_1.2.3.tgz"},
+			{"repo": "repo", "path": ".", "name": "packagePath_1.3.0.tgz"},
+			{"repo": "repo", "path": ".", "name": "otherPackage_2.0.0.tgz"}
+		]}`), 0o600))
+
+		jFrogManagerMock.On("SearchFiles", mock.Anything).
+			Run(func(args mock.Arguments) {
+				params := args.Get(0).(services.SearchParams)
+				assert.Equal(t, "repo/packagePath_*.tgz", params.Pattern)
+			}).Return(content.NewContentReader(results, content.DefaultKey), nil)
+		j := Downloader{
+			JFrogManager: jFrogManagerMock,
+		}
+
+		versions, err := j.ListVersions(context.Background(), internal.VersionsRequest{URI: "repo/packagePath"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1.2.3", "1.3.0"}, versions)
+	})
+}
+
+func TestNewManager(t *testing.T) {
+	t.Run("a username and password authenticate with basic auth", func(t *testing.T) {
+		j := Downloader{Config: internal.Config{JfrogURL: "url"}}
+
+		manager, err := j.newManager(context.Background(), internal.Credentials{Username: "tenant-user", Password: "tenant-password"})
+
+		require.NoError(t, err)
+		details := manager.GetConfig().GetServiceDetails()
+		assert.Equal(t, "tenant-user", details.GetUser())
+		assert.Equal(t, "tenant-password", details.GetPassword())
+		assert.Empty(t, details.GetAccessToken())
+	})
+
+	t.Run("a token is used as the access token", func(t *testing.T) {
+		j := Downloader{Config: internal.Config{JfrogURL: "url"}}
+
+		manager, err := j.newManager(context.Background(), internal.Credentials{Token: "tenant-token"})
+
+		require.NoError(t, err)
+		details := manager.GetConfig().GetServiceDetails()
+		assert.Equal(t, "tenant-token", details.GetAccessToken())
+		assert.Empty(t, details.GetPassword())
 	})
 }
 
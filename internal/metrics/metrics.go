@@ -0,0 +1,128 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics holds the Prometheus collectors of the operator. They are registered with the controller-runtime
+// metrics registry, so they are served on the metrics endpoint of the manager.
+package metrics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Values of the result label of the registrations counter
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+var (
+	// DownloadDuration is the time taken to download a workflow package, by download strategy
+	DownloadDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "flyteregistration_download_duration_seconds",
+		Help:    "Time taken to download a workflow package, by download strategy.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 10),
+	}, []string{"strategy"})
+
+	// DownloadSize is the size of the downloaded workflow packages, by download strategy
+	DownloadSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "flyteregistration_download_size_bytes",
+		Help:    "Size of the downloaded workflow packages, by download strategy.",
+		Buckets: prometheus.ExponentialBuckets(1024, 4, 10),
+	}, []string{"strategy"})
+
+	// RegistrationDuration is the time taken to register a workflow package with flyte admin
+	RegistrationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "flyteregistration_registration_duration_seconds",
+		Help:    "Time taken to register a workflow package with flyte admin.",
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 10),
+	}, []string{"project", "domain", "strategy"})
+
+	// Registrations counts the registrations of workflow packages by result
+	Registrations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "flyteregistration_registrations_total",
+		Help: "Number of workflow package registrations, by result.",
+	}, []string{"project", "domain", "strategy", "result"})
+
+	// Failed is the number of FlyteRegistrations whose last reconciliation failed
+	Failed = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "flyteregistration_failed",
+		Help: "Number of FlyteRegistrations whose last reconciliation failed.",
+	})
+
+	// FlytectlExitCodes counts the flytectl executions by command and exit code
+	FlytectlExitCodes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "flyteregistration_flytectl_exit_codes_total",
+		Help: "Number of flytectl executions, by command and exit code.",
+	}, []string{"command", "code"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		DownloadDuration,
+		DownloadSize,
+		RegistrationDuration,
+		Registrations,
+		Failed,
+		FlytectlExitCodes,
+	)
+}
+
+// failed holds the FlyteRegistrations whose last reconciliation failed, the Failed gauge is set to its size
+var failed = struct {
+	sync.Mutex
+	names map[types.NamespacedName]struct{}
+}{names: map[types.NamespacedName]struct{}{}}
+
+// SetFailed records whether the last reconciliation of a FlyteRegistration failed
+func SetFailed(name types.NamespacedName, isFailed bool) {
+	failed.Lock()
+	defer failed.Unlock()
+
+	if isFailed {
+		failed.names[name] = struct{}{}
+	} else {
+		delete(failed.names, name)
+	}
+	Failed.Set(float64(len(failed.names)))
+}
+
+// ObserveDownload records the duration and the size in bytes of a download
+func ObserveDownload(strategy string, duration time.Duration, size int64) {
+	DownloadDuration.WithLabelValues(strategy).Observe(duration.Seconds())
+	DownloadSize.WithLabelValues(strategy).Observe(float64(size))
+}
+
+// ObserveRegistration records the duration and the result of a registration
+func ObserveRegistration(project string, domain string, strategy string, duration time.Duration, err error) {
+	result := ResultSuccess
+	if err != nil {
+		result = ResultFailure
+	}
+
+	RegistrationDuration.WithLabelValues(project, domain, strategy).Observe(duration.Seconds())
+	Registrations.WithLabelValues(project, domain, strategy, result).Inc()
+}
+
+// ObserveFlytectl records the exit code of a flytectl command, -1 is recorded when flytectl did not run
+func ObserveFlytectl(command string, code int) {
+	FlytectlExitCodes.WithLabelValues(command, strconv.Itoa(code)).Inc()
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSetFailed(t *testing.T) {
+	first := types.NamespacedName{Namespace: "test", Name: "first"}
+	second := types.NamespacedName{Namespace: "test", Name: "second"}
+
+	SetFailed(first, true)
+	SetFailed(second, true)
+	SetFailed(first, true)
+	assert.Equal(t, float64(2), testutil.ToFloat64(Failed))
+
+	SetFailed(first, false)
+	assert.Equal(t, float64(1), testutil.ToFloat64(Failed))
+
+	SetFailed(second, false)
+	assert.Equal(t, float64(0), testutil.ToFloat64(Failed))
+}
+
+func TestObserveRegistration(t *testing.T) {
+	ObserveRegistration("test-project", "test-domain", "oci", time.Second, nil)
+	ObserveRegistration("test-project", "test-domain", "oci", time.Second, errors.New("test error"))
+	ObserveRegistration("test-project", "test-domain", "oci", time.Second, errors.New("test error"))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(Registrations.WithLabelValues("test-project", "test-domain", "oci", ResultSuccess)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(Registrations.WithLabelValues("test-project", "test-domain", "oci", ResultFailure)))
+}
+
+func TestObserveFlytectl(t *testing.T) {
+	ObserveFlytectl("register", 0)
+	ObserveFlytectl("register", 1)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(FlytectlExitCodes.WithLabelValues("register", "0")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(FlytectlExitCodes.WithLabelValues("register", "1")))
+}
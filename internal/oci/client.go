@@ -26,9 +26,12 @@ import (
 	"strings"
 
 	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/digest"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/signature"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content/file"
 	"oras.land/oras-go/v2/registry/remote"
@@ -39,75 +42,91 @@ import (
 
 // Downloader is a struct that exposes a function for downloading an artifact from OCI.
 type Downloader struct {
-	cfg internal.Config
+	cfg      internal.Config
+	verifier *signature.Verifier
 }
 
-// NewDownloader returns a downloader from a given config.
+// NewDownloader returns a downloader from a given config. The trusted keys the signatures of the artifacts are
+// verified against are loaded when they are configured.
 func NewDownloader(cfg internal.Config) (*Downloader, error) {
-	return &Downloader{
+	d := &Downloader{
 		cfg: cfg,
-	}, nil
+	}
+
+	if cfg.SignatureTrustedKeys != "" {
+		verifier, err := signature.LoadVerifier(cfg.SignatureTrustedKeys)
+		if err != nil {
+			return nil, err
+		}
+		d.verifier = verifier
+	}
+
+	return d, nil
 }
 
-// DownloadArtifact downloads an artifact from the OCI registry.
+// DownloadArtifact downloads an artifact from the OCI registry into the workspace of the request.
 // The uri is the path to the artifact in the OCI registry. For example adarga/ds-wf-relationships-extraction
 // The version is the version of the artifact to download. For example 0.1.0
-func (d *Downloader) DownloadArtifact(ctx context.Context, uri string, version string) (string, error) {
+// The credentials, when given, are used to authenticate statically instead of the configured auth strategy
+// The size of each blob is reserved in the workspace before it is copied, so downloads over the cap are refused
+// The artifact resolves to the digest of its manifest, a pinned digest may be the digest of the manifest or of the
+// downloaded layer
+// When the request requires a signature, the signatures of the manifest are verified before anything is downloaded
+// and the manifest is then downloaded by its digest, so the verified manifest is the one that is downloaded
+func (d *Downloader) DownloadArtifact(ctx context.Context, req internal.DownloadRequest) (internal.Artifact, error) {
+	uri := req.URI
+	version := req.Version
+
 	logger := log.FromContext(ctx)
 	logger.Info("downloading artifact...",
 		"artifact", uri,
 		"version", version,
 	)
 
-	// Create a new OCI repository. We need to create a new repository when we download a new artifact as each
-	// workflow package could be stored in a separate repository
-	repo, err := remote.NewRepository(fmt.Sprintf("%s/%s", d.cfg.OCIRegistry, uri))
+	repo, err := d.repository(ctx, uri, req.Credentials)
 	if err != nil {
-		return "", fmt.Errorf("failed to create OCI repository: %w", err)
+		return internal.Artifact{}, err
 	}
 
-	// Configure the authentication for the OCI repository. We need to do this each time we download an artifact
-	// to prevent the credentials from expiring
-	credential, err := getCredential(ctx, d.cfg)
+	// Download the artifact to a local file
+	basedir := req.Workspace.Dir
+	fs, err := file.New(basedir)
 	if err != nil {
-		return "", fmt.Errorf("failed to get OCI credentials: %w", err)
+		return internal.Artifact{}, fmt.Errorf("failed to create local file system: %w", err)
 	}
 
-	repo.Client = &auth.Client{
-		Client:     retry.DefaultClient,
-		Cache:      auth.NewCache(),
-		Credential: credential,
+	opts := oras.DefaultCopyOptions
+	opts.PreCopy = func(_ context.Context, desc ocispec.Descriptor) error {
+		return req.Workspace.Reserve(desc.Size)
 	}
 
-	// Delete any existing files in the directory before downloading the new artifact
-	base := filepath.Base(uri)
-	basedir := filepath.Join(os.TempDir(), base)
-	err = os.RemoveAll(basedir)
+	root, err := repo.Resolve(ctx, version)
 	if err != nil {
-		return "", fmt.Errorf("removing existing directory: %w", err)
+		return internal.Artifact{}, fmt.Errorf("failed to resolve artifact: %w", err)
 	}
 
-	// Download the artifact to a local file
-	fs, err := file.New(basedir)
-	if err != nil {
-		return "", fmt.Errorf("failed to create local file system: %w", err)
+	if req.VerifySignature {
+		if err := d.verifier.Verify(ctx, repo, root); err != nil {
+			return internal.Artifact{}, err
+		}
+		logger.Info("verified artifact signature", "artifact", uri, "version", version, "digest", root.Digest)
 	}
 
-	_, err = oras.Copy(ctx, repo, version, fs, version, oras.DefaultCopyOptions)
+	_, err = oras.Copy(ctx, repo, root.Digest.String(), fs, version, opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to copy artifact from remote: %w", err)
+		return internal.Artifact{}, fmt.Errorf("failed to copy artifact from remote: %w", err)
 	}
 
 	err = fs.Close()
 	if err != nil {
-		return "", fmt.Errorf("failed to close file store: %w", err)
+		return internal.Artifact{}, fmt.Errorf("failed to close file store: %w", err)
 	}
 
 	// Obtain the name of the downloaded file. Because the downloaded artifact is opaque to us, we need to
 	// read the directory and get the name of the file
 	files, err := os.ReadDir(basedir)
 	if err != nil {
-		return "", fmt.Errorf("failed to read downloaded files: %w", err)
+		return internal.Artifact{}, fmt.Errorf("failed to read downloaded files: %w", err)
 	}
 
 	if len(files) != 1 {
@@ -117,16 +136,82 @@ func (d *Downloader) DownloadArtifact(ctx context.Context, uri string, version s
 				"file name", file.Name(),
 			)
 		}
-		return "", fmt.Errorf("expected 1 file in the downloaded directory, got %d", len(files))
+		return internal.Artifact{}, fmt.Errorf("expected 1 file in the downloaded directory, got %d", len(files))
 	}
 
-	return filepath.Join(basedir, files[0].Name()), nil
+	artifactPath := filepath.Join(basedir, files[0].Name())
+	layerDigest, err := digest.File(artifactPath)
+	if err != nil {
+		return internal.Artifact{}, err
+	}
+
+	if err := digest.Verify(req.Digest, root.Digest.String(), layerDigest); err != nil {
+		return internal.Artifact{}, err
+	}
+
+	return internal.Artifact{Path: artifactPath, Digest: root.Digest.String()}, nil
 }
 
-func getCredential(ctx context.Context, cfg internal.Config) (auth.CredentialFunc, error) {
+// ListVersions lists the tags of the workflow package in the OCI registry.
+// The uri is the path to the artifact in the OCI registry, as for DownloadArtifact.
+func (d *Downloader) ListVersions(ctx context.Context, req internal.VersionsRequest) ([]string, error) {
+	repo, err := d.repository(ctx, req.URI, req.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	err = repo.Tags(ctx, "", func(tags []string) error {
+		versions = append(versions, tags...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags of %s: %w", req.URI, err)
+	}
+
+	return versions, nil
+}
+
+// repository returns the OCI repository of a workflow package, authenticated with the credentials when they are given
+func (d *Downloader) repository(ctx context.Context, uri string, credentials *internal.Credentials) (*remote.Repository, error) {
+	// Create a new OCI repository. We need to create a new repository when we download a new artifact as each
+	// workflow package could be stored in a separate repository
+	repo, err := remote.NewRepository(fmt.Sprintf("%s/%s", d.cfg.OCIRegistry, uri))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OCI repository: %w", err)
+	}
+
+	// Configure the authentication for the OCI repository. We need to do this each time we download an artifact
+	// to prevent the credentials from expiring
+	credential, err := getCredential(ctx, d.cfg, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OCI credentials: %w", err)
+	}
+
+	repo.Client = &auth.Client{
+		Client:     retry.DefaultClient,
+		Cache:      auth.NewCache(),
+		Credential: credential,
+	}
+
+	return repo, nil
+}
+
+func getCredential(ctx context.Context, cfg internal.Config, credentials *internal.Credentials) (auth.CredentialFunc, error) {
 	var username string
 	var password string
 
+	// A token is sent as the bearer token of the registry
+	if credentials != nil && credentials.Token != "" {
+		return auth.StaticCredential(cfg.OCIRegistry, auth.Credential{AccessToken: credentials.Token}), nil
+	}
+	if credentials != nil {
+		return auth.StaticCredential(cfg.OCIRegistry, auth.Credential{
+			Username: credentials.Username,
+			Password: credentials.Password,
+		}), nil
+	}
+
 	switch cfg.OCIAuthStrategy {
 	case internal.OCIAuthStrategyStatic:
 		username = cfg.OCIUsername
@@ -34,7 +34,7 @@ func TestGetCredentials(t *testing.T) {
 			OCIAuthStrategy: "static",
 		}
 
-		credsFunc, err := getCredential(context.Background(), cfg)
+		credsFunc, err := getCredential(context.Background(), cfg, nil)
 		require.NoError(t, err)
 
 		creds, err := credsFunc(context.Background(), "localhost:1234")
@@ -43,4 +43,38 @@ func TestGetCredentials(t *testing.T) {
 		assert.Equal(t, "test-username", creds.Username)
 		assert.Equal(t, "test-password", creds.Password)
 	})
+	t.Run("credentials override the auth strategy", func(t *testing.T) {
+		cfg := internal.Config{
+			OCIRegistry:     "localhost:1234",
+			OCIAuthStrategy: "ecr",
+		}
+
+		credsFunc, err := getCredential(context.Background(), cfg, &internal.Credentials{
+			Username: "tenant-username",
+			Password: "tenant-password",
+		})
+		require.NoError(t, err)
+
+		creds, err := credsFunc(context.Background(), "localhost:1234")
+		require.NoError(t, err)
+
+		assert.Equal(t, "tenant-username", creds.Username)
+		assert.Equal(t, "tenant-password", creds.Password)
+	})
+	t.Run("a token is used as the access token of the registry", func(t *testing.T) {
+		cfg := internal.Config{
+			OCIRegistry:     "localhost:1234",
+			OCIAuthStrategy: "ecr",
+		}
+
+		credsFunc, err := getCredential(context.Background(), cfg, &internal.Credentials{Token: "tenant-token"})
+		require.NoError(t, err)
+
+		creds, err := credsFunc(context.Background(), "localhost:1234")
+		require.NoError(t, err)
+
+		assert.Equal(t, "tenant-token", creds.AccessToken)
+		assert.Empty(t, creds.Username)
+		assert.Empty(t, creds.Password)
+	})
 }
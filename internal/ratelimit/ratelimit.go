@@ -0,0 +1,102 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit holds the token bucket limiters that keep concurrent reconciliations from overloading the
+// registries and flyte admin, each host gets a token bucket of its own
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter is a token bucket limiter per host. A nil Limiter does not limit.
+type Limiter struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewLimiter returns a Limiter that allows requestsPerSecond requests to each host, with bursts of up to burst
+// requests. There is no limit when requestsPerSecond is 0.
+func NewLimiter(requestsPerSecond float64, burst int) *Limiter {
+	limit := rate.Limit(requestsPerSecond)
+	if requestsPerSecond <= 0 {
+		limit = rate.Inf
+	}
+
+	return &Limiter{
+		limit:    limit,
+		burst:    burst,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+// Wait blocks until the token bucket of the host allows a request, or the context is done
+func (l *Limiter) Wait(ctx context.Context, host string) error {
+	if l == nil {
+		return nil
+	}
+
+	if err := l.limiter(host).Wait(ctx); err != nil {
+		return fmt.Errorf("waiting for the rate limit of %s: %w", host, err)
+	}
+
+	return nil
+}
+
+// limiter returns the token bucket of the host, creating it on the first request
+func (l *Limiter) limiter(host string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, ok := l.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[host] = limiter
+	}
+
+	return limiter
+}
+
+// Host returns the host of an endpoint, which may be a URL such as `https://example.jfrog.io/artifactory` or
+// `dns:///flyte.example.com`, or a bare host such as `1234.dkr.ecr.eu-west-1.amazonaws.com`
+func Host(endpoint string) string {
+	if !strings.Contains(endpoint, "://") {
+		host, _, _ := strings.Cut(endpoint, "/")
+		return host
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+
+	if u.Host == "" {
+		// dns:///host has the host in the path
+		host, _, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+		return host
+	}
+
+	return u.Host
+}
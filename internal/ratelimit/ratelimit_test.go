@@ -0,0 +1,63 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWait(t *testing.T) {
+	t.Run("each host has a token bucket of its own", func(t *testing.T) {
+		limiter := NewLimiter(0.001, 1)
+
+		assert.NoError(t, limiter.Wait(context.Background(), "first.example.com"))
+		assert.NoError(t, limiter.Wait(context.Background(), "second.example.com"))
+	})
+
+	t.Run("requests over the limit wait for a token", func(t *testing.T) {
+		limiter := NewLimiter(0.001, 1)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.NoError(t, limiter.Wait(ctx, "example.com"))
+		assert.ErrorContains(t, limiter.Wait(ctx, "example.com"), "waiting for the rate limit of example.com")
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		limiter := NewLimiter(0, 0)
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, limiter.Wait(context.Background(), "example.com"))
+		}
+	})
+
+	t.Run("nil limiter", func(t *testing.T) {
+		var limiter *Limiter
+		assert.NoError(t, limiter.Wait(context.Background(), "example.com"))
+	})
+}
+
+func TestHost(t *testing.T) {
+	assert.Equal(t, "1234.dkr.ecr.eu-west-1.amazonaws.com", Host("1234.dkr.ecr.eu-west-1.amazonaws.com"))
+	assert.Equal(t, "registry.example.com:5000", Host("registry.example.com:5000/adarga"))
+	assert.Equal(t, "example.jfrog.io", Host("https://example.jfrog.io/artifactory"))
+	assert.Equal(t, "flyte.example.com", Host("dns://flyte.example.com"))
+	assert.Equal(t, "flyte.example.com:443", Host("dns:///flyte.example.com:443"))
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s3 contains a struct used for downloading artifacts from S3, or from S3 compatible object storage
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/digest"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/signature"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Downloader is a struct that exposes a function for downloading an artifact from S3.
+type Downloader struct {
+	cfg    internal.Config
+	awsCfg aws.Config
+}
+
+// NewDownloader returns a downloader from a given config. The AWS credentials are loaded from the environment, e.g.
+// from the service account of the operator, in the configured region.
+func NewDownloader(ctx context.Context, cfg internal.Config) (*Downloader, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(cfg.S3Region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	return &Downloader{
+		cfg:    cfg,
+		awsCfg: awsCfg,
+	}, nil
+}
+
+// Object is the location of an artifact in S3
+type Object struct {
+	Bucket    string
+	Key       string
+	VersionID string
+}
+
+// ParseURI returns the object of an S3 URI without its scheme, e.g. `bucket/path/{version}/package.tgz?versionId=abc`.
+// The `{version}` placeholder in the key is replaced with the version, keys without it get the `_<version>.tgz`
+// suffix JFrog packages are stored with. The optional versionId query parameter pins a version of the object.
+func ParseURI(uri string, version string) (Object, error) {
+	location, query, _ := strings.Cut(uri, "?")
+
+	bucket, key, _ := strings.Cut(location, "/")
+	if bucket == "" || key == "" {
+		return Object{}, fmt.Errorf("invalid S3 URI %s, expected bucket/key", uri)
+	}
+
+	if strings.Contains(key, internal.VersionPlaceholder) {
+		key = strings.ReplaceAll(key, internal.VersionPlaceholder, version)
+	} else {
+		key = fmt.Sprintf("%s_%s.tgz", key, version)
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return Object{}, fmt.Errorf("invalid S3 URI %s: %w", uri, err)
+	}
+
+	return Object{Bucket: bucket, Key: key, VersionID: params.Get("versionId")}, nil
+}
+
+// DownloadArtifact downloads an artifact from S3 into the workspace of the request.
+// The uri is the bucket and the key of the artifact, see ParseURI.
+// The credentials, when given, are used as the access key ID and secret access key instead of the AWS credentials
+// of the operator
+// The size of the object is reserved in the workspace before it is written, so downloads over the cap are refused
+// The artifact resolves to the sha256 digest of the downloaded file, its ETag and version ID are recorded with it.
+// The SDK validates the checksum of the object when S3 has one.
+// Signatures are only supported for OCI artifacts, so artifacts that must be signed are refused.
+// S3 cannot authenticate with a bearer token, so token credentials are refused instead of being ignored.
+func (d *Downloader) DownloadArtifact(ctx context.Context, req internal.DownloadRequest) (internal.Artifact, error) {
+	if req.VerifySignature {
+		return internal.Artifact{}, fmt.Errorf("%w: signatures can only be verified for oci packages", signature.ErrUnverified)
+	}
+	if req.Credentials != nil && req.Credentials.Token != "" {
+		return internal.Artifact{}, fmt.Errorf("%w for s3 packages", internal.ErrTokenUnsupported)
+	}
+
+	object, err := ParseURI(req.URI, req.Version)
+	if err != nil {
+		return internal.Artifact{}, err
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("downloading artifact...",
+		"bucket", object.Bucket,
+		"key", object.Key,
+		"versionId", object.VersionID,
+	)
+
+	input := &awss3.GetObjectInput{
+		Bucket:       aws.String(object.Bucket),
+		Key:          aws.String(object.Key),
+		ChecksumMode: types.ChecksumModeEnabled,
+	}
+	if object.VersionID != "" {
+		input.VersionId = aws.String(object.VersionID)
+	}
+
+	output, err := d.client(req.Credentials).GetObject(ctx, input)
+	if err != nil {
+		return internal.Artifact{}, fmt.Errorf("failed to get object s3://%s/%s: %w", object.Bucket, object.Key, err)
+	}
+	defer output.Body.Close()
+
+	if err := req.Workspace.Reserve(aws.ToInt64(output.ContentLength)); err != nil {
+		return internal.Artifact{}, err
+	}
+
+	artifactPath := filepath.Join(req.Workspace.Dir, path.Base(object.Key))
+	if err := writeFile(artifactPath, output.Body); err != nil {
+		return internal.Artifact{}, err
+	}
+
+	fileDigest, err := digest.File(artifactPath)
+	if err != nil {
+		return internal.Artifact{}, err
+	}
+
+	if err := digest.Verify(req.Digest, fileDigest); err != nil {
+		return internal.Artifact{}, err
+	}
+
+	return internal.Artifact{
+		Path:      artifactPath,
+		Digest:    fileDigest,
+		ETag:      strings.Trim(aws.ToString(output.ETag), `"`),
+		VersionID: aws.ToString(output.VersionId),
+	}, nil
+}
+
+// ListVersions is not supported, the versions of a package URI with a `{version}` placeholder cannot be listed from
+// S3, so version policies can only be used with the OCI and JFrog strategies.
+func (d *Downloader) ListVersions(_ context.Context, _ internal.VersionsRequest) ([]string, error) {
+	return nil, fmt.Errorf("%w for s3 packages", internal.ErrVersionsUnsupported)
+}
+
+// client returns an S3 client, authenticated with the credentials when they are given. A custom endpoint is used for
+// S3 compatible object storage.
+func (d *Downloader) client(creds *internal.Credentials) *awss3.Client {
+	return awss3.NewFromConfig(d.awsCfg, func(o *awss3.Options) {
+		if d.cfg.S3Endpoint != "" {
+			o.BaseEndpoint = aws.String(d.cfg.S3Endpoint)
+		}
+		o.UsePathStyle = d.cfg.S3UsePathStyle
+
+		if creds != nil {
+			o.Credentials = credentials.NewStaticCredentialsProvider(creds.Username, creds.Password, "")
+		}
+	})
+}
+
+// writeFile writes the body of an object to a file
+func writeFile(path string, body io.Reader) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, body); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/digest"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/signature"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// object is an object stored in the S3 stand-in
+type object struct {
+	body      string
+	versionID string
+	checksum  string
+}
+
+// newS3 starts a stand-in for S3 serving the objects by `/bucket/key` path, and at `?versionId=` for their version.
+// It records the authorization header of the last request.
+func newS3(t *testing.T, objects map[string]object) (*httptest.Server, *string) {
+	t.Helper()
+	var authorization string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+
+		obj, ok := objects[r.URL.Path]
+		if versionID := r.URL.Query().Get("versionId"); versionID != "" {
+			obj, ok = objects[r.URL.Path+"?versionId="+versionID]
+		}
+		if !ok || r.Method != http.MethodGet {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+
+		w.Header().Set("ETag", `"test-etag"`)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.body)))
+		if obj.versionID != "" {
+			w.Header().Set("x-amz-version-id", obj.versionID)
+		}
+		if obj.checksum != "" {
+			w.Header().Set("x-amz-checksum-sha256", obj.checksum)
+		}
+		_, _ = w.Write([]byte(obj.body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &authorization
+}
+
+// newDownloader returns a downloader for the S3 stand-in, authenticated with static AWS credentials
+func newDownloader(t *testing.T, endpoint string) *Downloader {
+	t.Helper()
+	t.Setenv("AWS_ACCESS_KEY_ID", "test-access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test-secret-key")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+
+	d, err := NewDownloader(context.Background(), internal.Config{
+		S3Region:       "eu-west-1",
+		S3Endpoint:     endpoint,
+		S3UsePathStyle: true,
+	})
+	require.NoError(t, err)
+	return d
+}
+
+func TestDownloadArtifact(t *testing.T) {
+	packageDigest := "sha256:bc4a71180870f7945155fbb02f4b0a2e3faa2a62d6d31b7039013055ed19869a"
+	checksum := sha256.Sum256([]byte("package"))
+
+	server, authorization := newS3(t, map[string]object{
+		"/packages/data-warehouse_1.2.3.tgz":                      {body: "package", checksum: base64.StdEncoding.EncodeToString(checksum[:])},
+		"/packages/data-warehouse/1.2.3/package.tgz":              {body: "package", versionID: "latest"},
+		"/packages/data-warehouse/1.2.3/package.tgz?versionId=v1": {body: "package", versionID: "v1"},
+		"/packages/corrupted_1.2.3.tgz":                           {body: "package", checksum: base64.StdEncoding.EncodeToString([]byte("not the checksum of the package"))},
+	})
+	d := newDownloader(t, server.URL)
+	workspaces := workspace.NewManager(t.TempDir(), 0)
+
+	t.Run("we can download an object successfully", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		artifact, err := d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "packages/data-warehouse", Version: "1.2.3", Workspace: ws})
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(ws.Dir, "data-warehouse_1.2.3.tgz"), artifact.Path)
+		assert.Equal(t, packageDigest, artifact.Digest)
+		assert.Equal(t, "test-etag", artifact.ETag)
+		assert.Contains(t, *authorization, "Credential=test-access-key/")
+
+		data, err := os.ReadFile(artifact.Path)
+		require.NoError(t, err)
+		assert.Equal(t, "package", string(data))
+	})
+
+	t.Run("the version is templated into the key", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		artifact, err := d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "packages/data-warehouse/{version}/package.tgz", Version: "1.2.3", Workspace: ws})
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(ws.Dir, "package.tgz"), artifact.Path)
+		assert.Equal(t, "latest", artifact.VersionID)
+	})
+
+	t.Run("a version of the object can be pinned", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		artifact, err := d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "packages/data-warehouse/{version}/package.tgz?versionId=v1", Version: "1.2.3", Workspace: ws})
+
+		require.NoError(t, err)
+		assert.Equal(t, "v1", artifact.VersionID)
+	})
+
+	t.Run("credentials override the AWS credentials", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{
+			URI:         "packages/data-warehouse",
+			Version:     "1.2.3",
+			Workspace:   ws,
+			Credentials: &internal.Credentials{Username: "tenant-access-key", Password: "tenant-secret-key"},
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, *authorization, "Credential=tenant-access-key/")
+	})
+
+	t.Run("token credentials are refused", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{
+			URI:         "packages/data-warehouse",
+			Version:     "1.2.3",
+			Workspace:   ws,
+			Credentials: &internal.Credentials{Token: "tenant-token"},
+		})
+
+		assert.ErrorIs(t, err, internal.ErrTokenUnsupported)
+	})
+
+	t.Run("missing objects fail the download", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "packages/missing", Version: "1.2.3", Workspace: ws})
+
+		assert.ErrorContains(t, err, "failed to get object s3://packages/missing_1.2.3.tgz")
+	})
+
+	t.Run("objects that do not match their checksum are refused", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "packages/corrupted", Version: "1.2.3", Workspace: ws})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("objects without the pinned digest are refused", func(t *testing.T) {
+		ws, err := workspaces.Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{
+			URI:       "packages/data-warehouse",
+			Version:   "1.2.3",
+			Workspace: ws,
+			Digest:    "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+		})
+
+		assert.ErrorIs(t, err, digest.ErrMismatch)
+	})
+
+	t.Run("downloads over the workspace cap are refused", func(t *testing.T) {
+		ws, err := workspace.NewManager(t.TempDir(), 4).Create("test")
+		require.NoError(t, err)
+
+		_, err = d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "packages/data-warehouse", Version: "1.2.3", Workspace: ws})
+
+		assert.ErrorIs(t, err, workspace.ErrCapExceeded)
+	})
+
+	t.Run("objects that must be signed are refused", func(t *testing.T) {
+		_, err := d.DownloadArtifact(context.Background(), internal.DownloadRequest{URI: "packages/data-warehouse", Version: "1.2.3", VerifySignature: true})
+
+		assert.ErrorIs(t, err, signature.ErrUnverified)
+	})
+}
+
+func TestParseURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		want    Object
+		wantErr string
+	}{
+		{
+			name: "keys without a placeholder get the version suffix",
+			uri:  "packages/data-warehouse",
+			want: Object{Bucket: "packages", Key: "data-warehouse_1.2.3.tgz"},
+		},
+		{
+			name: "the placeholder is replaced with the version",
+			uri:  "packages/{version}/data-warehouse-{version}.tgz",
+			want: Object{Bucket: "packages", Key: "1.2.3/data-warehouse-1.2.3.tgz"},
+		},
+		{
+			name: "the version ID is read from the query",
+			uri:  "packages/{version}/package.tgz?versionId=abc",
+			want: Object{Bucket: "packages", Key: "1.2.3/package.tgz", VersionID: "abc"},
+		},
+		{
+			name:    "a bucket without a key is invalid",
+			uri:     "packages",
+			wantErr: "invalid S3 URI packages, expected bucket/key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURI(tt.uri, "1.2.3")
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
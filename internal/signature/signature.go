@@ -0,0 +1,517 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package signature verifies the signatures of OCI workflow packages before they are registered.
+
+The signatures of a package are the artifacts referring to its manifest, listed with the OCI referrers API, and the
+signature cosign pushes to the `sha256-<digest>.sig` tag when the registry does not support referrers. A package is
+verified when one of its signatures was made by a trusted key:
+
+  - cosign signatures are verified against the trusted public keys, or against the key of the signing certificate
+    attached to the signature when that certificate chains to a trusted certificate
+  - Notation signatures in the JWS envelope are verified against the certificate chain in the envelope, which must
+    chain to a trusted certificate
+
+The signed payload must name the digest of the package manifest, so a signature can not be reused for other content.
+*/
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+)
+
+// Media types and annotations of the signatures made by cosign and Notation
+const (
+	// MediaTypeCosignPayload is the media type of the layer holding the payload signed by cosign
+	MediaTypeCosignPayload = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// AnnotationCosignSignature is the annotation of a cosign layer with the base64 signature of its payload
+	AnnotationCosignSignature = "dev.cosignproject.cosign/signature"
+	// AnnotationCosignCertificate is the annotation of a cosign layer with the PEM signing certificate
+	AnnotationCosignCertificate = "dev.sigstore.cosign/certificate"
+	// AnnotationCosignChain is the annotation of a cosign layer with the PEM chain of the signing certificate
+	AnnotationCosignChain = "dev.sigstore.cosign/chain"
+	// MediaTypeNotationJWS is the media type of the layer holding a Notation signature in the JWS envelope
+	MediaTypeNotationJWS = "application/jose+json"
+	// MediaTypeNotationPayload is the content type of the payload signed by Notation
+	MediaTypeNotationPayload = "application/vnd.cncf.notary.payload.v1+json"
+)
+
+// maxSignatureSize caps the size of the signature manifests and layers that are fetched
+const maxSignatureSize = 4 << 20
+
+// ErrUnverified is returned when a workflow package does not have a signature made by a trusted key
+var ErrUnverified = errors.New("signature verification failed")
+
+// Repository is the repository of a workflow package, it lists the referrers of the package and resolves tags
+type Repository interface {
+	content.ReadOnlyGraphStorage
+	content.Resolver
+}
+
+// Verifier verifies signatures against trusted public keys and certificates. A nil Verifier trusts nothing.
+type Verifier struct {
+	keys  []crypto.PublicKey
+	roots *x509.CertPool
+}
+
+// LoadVerifier returns a Verifier trusting the PEM public keys and certificates in a file, or in the files of a
+// directory such as a mounted Secret
+func LoadVerifier(path string) (*Verifier, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trusted keys: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trusted keys: %w", err)
+		}
+
+		files = nil
+		for _, entry := range entries {
+			// Mounted Secrets and ConfigMaps hold their data in hidden directories, linked from the visible files
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			if info, err := os.Stat(filepath.Join(path, entry.Name())); err != nil || info.IsDir() {
+				continue
+			}
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	var data [][]byte
+	for _, file := range files {
+		pemData, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trusted keys: %w", err)
+		}
+		data = append(data, pemData)
+	}
+
+	return NewVerifier(data...)
+}
+
+// NewVerifier returns a Verifier trusting the `PUBLIC KEY` and `CERTIFICATE` blocks of PEM data
+func NewVerifier(pemData ...[]byte) (*Verifier, error) {
+	v := &Verifier{roots: x509.NewCertPool()}
+
+	var certificates int
+	for _, data := range pemData {
+		for {
+			var block *pem.Block
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+
+			switch block.Type {
+			case "PUBLIC KEY":
+				key, err := x509.ParsePKIXPublicKey(block.Bytes)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse trusted public key: %w", err)
+				}
+				v.keys = append(v.keys, key)
+			case "CERTIFICATE":
+				certificate, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse trusted certificate: %w", err)
+				}
+				v.roots.AddCert(certificate)
+				certificates++
+			default:
+				return nil, fmt.Errorf("unsupported PEM block %s in trusted keys, only PUBLIC KEY and CERTIFICATE allowed", block.Type)
+			}
+		}
+	}
+
+	if len(v.keys) == 0 && certificates == 0 {
+		return nil, errors.New("no trusted public keys or certificates found")
+	}
+
+	if certificates == 0 {
+		v.roots = nil
+	}
+
+	return v, nil
+}
+
+// Verify returns nil when one of the signatures of the package with the subject manifest was made by a trusted key,
+// and an error wrapping ErrUnverified otherwise
+func (v *Verifier) Verify(ctx context.Context, repo Repository, subject ocispec.Descriptor) error {
+	if v == nil {
+		return fmt.Errorf("%w: no trusted public keys or certificates are configured", ErrUnverified)
+	}
+
+	signatures, err := registry.Referrers(ctx, repo, subject, "")
+	if err != nil {
+		return fmt.Errorf("failed to list the signatures of %s: %w", subject.Digest, err)
+	}
+
+	// cosign tags the signature with the digest of the package when the registry does not support referrers
+	tagged, err := repo.Resolve(ctx, CosignTag(subject))
+	switch {
+	case err == nil:
+		signatures = append(signatures, tagged)
+	case !errors.Is(err, errdef.ErrNotFound):
+		return fmt.Errorf("failed to resolve the cosign signature of %s: %w", subject.Digest, err)
+	}
+
+	if len(signatures) == 0 {
+		return fmt.Errorf("%w: %s is not signed", ErrUnverified, subject.Digest)
+	}
+
+	var failures []string
+	for _, desc := range signatures {
+		err := v.verifySignature(ctx, repo, subject, desc)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", desc.Digest, err))
+	}
+
+	return fmt.Errorf("%w: no trusted signature of %s: %s", ErrUnverified, subject.Digest, strings.Join(failures, "; "))
+}
+
+// CosignTag returns the tag cosign pushes the signature of the subject manifest to
+func CosignTag(subject ocispec.Descriptor) string {
+	return fmt.Sprintf("%s-%s.sig", subject.Digest.Algorithm(), subject.Digest.Encoded())
+}
+
+// verifySignature verifies the signature manifest, it is verified when one of its layers is
+func (v *Verifier) verifySignature(ctx context.Context, repo Repository, subject ocispec.Descriptor, desc ocispec.Descriptor) error {
+	data, err := fetch(ctx, repo, desc)
+	if err != nil {
+		return err
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("failed to parse signature manifest: %w", err)
+	}
+
+	var failures []string
+	for _, layer := range manifest.Layers {
+		switch layer.MediaType {
+		case MediaTypeCosignPayload:
+			err = v.verifyCosign(ctx, repo, subject, layer)
+		case MediaTypeNotationJWS:
+			err = v.verifyNotation(ctx, repo, subject, layer)
+		default:
+			err = fmt.Errorf("unsupported signature media type %s", layer.MediaType)
+		}
+
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, err.Error())
+	}
+
+	if len(failures) == 0 {
+		return errors.New("no signature layers")
+	}
+
+	return errors.New(strings.Join(failures, ", "))
+}
+
+// cosignPayload is the simple signing payload signed by cosign
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifyCosign verifies a cosign signature layer, whose annotations hold the signature of the payload in the layer
+func (v *Verifier) verifyCosign(ctx context.Context, repo Repository, subject ocispec.Descriptor, layer ocispec.Descriptor) error {
+	sig, err := base64.StdEncoding.DecodeString(layer.Annotations[AnnotationCosignSignature])
+	if err != nil || len(sig) == 0 {
+		return errors.New("cosign signature annotation is missing or invalid")
+	}
+
+	payload, err := fetch(ctx, repo, layer)
+	if err != nil {
+		return err
+	}
+
+	// The verifier is shared by concurrent reconciliations, the key of the certificate must not be appended to its keys
+	keys := slices.Clip(v.keys)
+	if pemCertificate := layer.Annotations[AnnotationCosignCertificate]; pemCertificate != "" {
+		certificate, err := v.verifyChain(parsePEMCertificates(pemCertificate), parsePEMCertificates(layer.Annotations[AnnotationCosignChain]))
+		if err != nil {
+			return err
+		}
+		keys = append(keys, certificate.PublicKey)
+	}
+
+	if !verifyAny(keys, payload, sig) {
+		return errors.New("cosign signature was not made by a trusted key")
+	}
+
+	var p cosignPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("failed to parse cosign payload: %w", err)
+	}
+
+	if p.Critical.Image.DockerManifestDigest != subject.Digest.String() {
+		return fmt.Errorf("cosign signature is for %s", p.Critical.Image.DockerManifestDigest)
+	}
+
+	return nil
+}
+
+// jwsEnvelope is a Notation signature in the flattened JWS JSON serialization
+type jwsEnvelope struct {
+	Payload   string `json:"payload"`
+	Protected string `json:"protected"`
+	Signature string `json:"signature"`
+	Header    struct {
+		// X5C is the certificate chain of the signing key, leaf first
+		X5C [][]byte `json:"x5c"`
+	} `json:"header"`
+}
+
+// jwsProtectedHeader is the part of the protected header of a Notation signature that is verified
+type jwsProtectedHeader struct {
+	Algorithm   string `json:"alg"`
+	ContentType string `json:"cty"`
+}
+
+// notationPayload is the payload signed by Notation
+type notationPayload struct {
+	TargetArtifact ocispec.Descriptor `json:"targetArtifact"`
+}
+
+// verifyNotation verifies a Notation signature layer holding a JWS envelope
+func (v *Verifier) verifyNotation(ctx context.Context, repo Repository, subject ocispec.Descriptor, layer ocispec.Descriptor) error {
+	data, err := fetch(ctx, repo, layer)
+	if err != nil {
+		return err
+	}
+
+	var envelope jwsEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("failed to parse notation envelope: %w", err)
+	}
+
+	var certificates []*x509.Certificate
+	for _, der := range envelope.Header.X5C {
+		certificate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("failed to parse notation certificate: %w", err)
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	leaf, err := v.verifyChain(certificates, nil)
+	if err != nil {
+		return err
+	}
+
+	protected, err := base64.RawURLEncoding.DecodeString(envelope.Protected)
+	if err != nil {
+		return fmt.Errorf("failed to decode notation protected header: %w", err)
+	}
+
+	var header jwsProtectedHeader
+	if err := json.Unmarshal(protected, &header); err != nil {
+		return fmt.Errorf("failed to parse notation protected header: %w", err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(envelope.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode notation signature: %w", err)
+	}
+
+	if err := verifyJWS(header.Algorithm, leaf.PublicKey, []byte(envelope.Protected+"."+envelope.Payload), sig); err != nil {
+		return err
+	}
+
+	if header.ContentType != MediaTypeNotationPayload {
+		return fmt.Errorf("unsupported notation payload content type %s", header.ContentType)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to decode notation payload: %w", err)
+	}
+
+	var p notationPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("failed to parse notation payload: %w", err)
+	}
+
+	if p.TargetArtifact.Digest != subject.Digest {
+		return fmt.Errorf("notation signature is for %s", p.TargetArtifact.Digest)
+	}
+
+	return nil
+}
+
+// verifyChain verifies that the first certificate is a code signing certificate chaining to a trusted certificate,
+// through the other certificates and the intermediate certificates, and returns it
+func (v *Verifier) verifyChain(certificates []*x509.Certificate, intermediates []*x509.Certificate) (*x509.Certificate, error) {
+	if len(certificates) == 0 {
+		return nil, errors.New("no signing certificate")
+	}
+
+	if v.roots == nil {
+		return nil, errors.New("no trusted certificates are configured")
+	}
+
+	pool := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		pool.AddCert(certificate)
+	}
+	for _, certificate := range intermediates {
+		pool.AddCert(certificate)
+	}
+
+	leaf := certificates[0]
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: pool,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing certificate is not trusted: %w", err)
+	}
+
+	return leaf, nil
+}
+
+// verifyAny returns true when the signature of the payload was made by one of the keys, the way cosign signs
+func verifyAny(keys []crypto.PublicKey, payload []byte, sig []byte) bool {
+	digest := sha256.Sum256(payload)
+
+	for _, key := range keys {
+		var ok bool
+		switch key := key.(type) {
+		case *ecdsa.PublicKey:
+			ok = ecdsa.VerifyASN1(key, digest[:], sig)
+		case *rsa.PublicKey:
+			ok = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+		case ed25519.PublicKey:
+			ok = ed25519.Verify(key, payload, sig)
+		}
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verifyJWS verifies the signature of a JWS signing input with one of the algorithms allowed by Notation
+func verifyJWS(algorithm string, key crypto.PublicKey, signingInput []byte, sig []byte) error {
+	var hash crypto.Hash
+	switch algorithm {
+	case "PS256", "ES256":
+		hash = crypto.SHA256
+	case "PS384", "ES384":
+		hash = crypto.SHA384
+	case "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported notation signature algorithm %s", algorithm)
+	}
+
+	h := hash.New()
+	h.Write(signingInput)
+	digest := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(algorithm, "PS") {
+			return fmt.Errorf("notation signature algorithm %s does not match an RSA key", algorithm)
+		}
+		if err := rsa.VerifyPSS(key, hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+			return errors.New("notation signature is invalid")
+		}
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(algorithm, "ES") || len(sig)%2 != 0 {
+			return fmt.Errorf("notation signature algorithm %s does not match an ECDSA key", algorithm)
+		}
+		// JWS ECDSA signatures are the concatenated r and s values
+		r := new(big.Int).SetBytes(sig[:len(sig)/2])
+		s := new(big.Int).SetBytes(sig[len(sig)/2:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errors.New("notation signature is invalid")
+		}
+	default:
+		return fmt.Errorf("unsupported notation signing key %T", key)
+	}
+
+	return nil
+}
+
+// parsePEMCertificates parses the certificates in PEM data, skipping anything else
+func parsePEMCertificates(data string) []*x509.Certificate {
+	var certificates []*x509.Certificate
+
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return certificates
+		}
+
+		if certificate, err := x509.ParseCertificate(block.Bytes); err == nil && block.Type == "CERTIFICATE" {
+			certificates = append(certificates, certificate)
+		}
+	}
+}
+
+// fetch fetches and verifies the content of a signature manifest or layer, refusing oversized content
+func fetch(ctx context.Context, repo Repository, desc ocispec.Descriptor) ([]byte, error) {
+	if desc.Size > maxSignatureSize {
+		return nil, fmt.Errorf("signature content %s is too large: %d bytes", desc.Digest, desc.Size)
+	}
+
+	data, err := content.FetchAll(ctx, repo, desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signature content %s: %w", desc.Digest, err)
+	}
+
+	return data, nil
+}
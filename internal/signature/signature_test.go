@@ -0,0 +1,348 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+
+	key := generateKey(t)
+	untrustedKey := generateKey(t)
+	root, rootKey := generateCertificate(t, nil, nil, true)
+	leaf, leafKey := generateCertificate(t, root, rootKey, false)
+	untrustedRoot, untrustedRootKey := generateCertificate(t, nil, nil, true)
+	untrustedLeaf, untrustedLeafKey := generateCertificate(t, untrustedRoot, untrustedRootKey, false)
+
+	verifier, err := NewVerifier(publicKeyPEM(t, &key.PublicKey), certificatePEM(root))
+	require.NoError(t, err)
+
+	t.Run("a cosign signature made by a trusted key is verified", func(t *testing.T) {
+		store, subject := newPackage(t)
+		pushCosignSignature(t, store, subject, subject, key, nil, true)
+
+		assert.NoError(t, verifier.Verify(ctx, store, subject))
+	})
+
+	t.Run("a cosign signature pushed to the signature tag is verified", func(t *testing.T) {
+		store, subject := newPackage(t)
+		pushCosignSignature(t, store, subject, subject, key, nil, false)
+
+		assert.NoError(t, verifier.Verify(ctx, store, subject))
+	})
+
+	t.Run("a cosign signature with a trusted certificate is verified", func(t *testing.T) {
+		store, subject := newPackage(t)
+		pushCosignSignature(t, store, subject, subject, leafKey, leaf, true)
+
+		assert.NoError(t, verifier.Verify(ctx, store, subject))
+	})
+
+	t.Run("a notation signature with a trusted certificate is verified", func(t *testing.T) {
+		store, subject := newPackage(t)
+		pushNotationSignature(t, store, subject, subject, leafKey, leaf)
+
+		assert.NoError(t, verifier.Verify(ctx, store, subject))
+	})
+
+	t.Run("a package without signatures is not verified", func(t *testing.T) {
+		store, subject := newPackage(t)
+
+		err := verifier.Verify(ctx, store, subject)
+
+		assert.ErrorIs(t, err, ErrUnverified)
+		assert.ErrorContains(t, err, "is not signed")
+	})
+
+	t.Run("a cosign signature made by an untrusted key is not verified", func(t *testing.T) {
+		store, subject := newPackage(t)
+		pushCosignSignature(t, store, subject, subject, untrustedKey, nil, true)
+
+		assert.ErrorIs(t, verifier.Verify(ctx, store, subject), ErrUnverified)
+	})
+
+	t.Run("a cosign signature of another package is not verified", func(t *testing.T) {
+		store, subject := newPackage(t)
+		other := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"}
+		pushCosignSignature(t, store, subject, other, key, nil, true)
+
+		err := verifier.Verify(ctx, store, subject)
+
+		assert.ErrorIs(t, err, ErrUnverified)
+		assert.ErrorContains(t, err, "cosign signature is for sha256:1111111111111111111111111111111111111111111111111111111111111111")
+	})
+
+	t.Run("a notation signature with an untrusted certificate is not verified", func(t *testing.T) {
+		store, subject := newPackage(t)
+		pushNotationSignature(t, store, subject, subject, untrustedLeafKey, untrustedLeaf)
+
+		err := verifier.Verify(ctx, store, subject)
+
+		assert.ErrorIs(t, err, ErrUnverified)
+		assert.ErrorContains(t, err, "signing certificate is not trusted")
+	})
+
+	t.Run("nothing is verified without trusted keys", func(t *testing.T) {
+		store, subject := newPackage(t)
+		pushCosignSignature(t, store, subject, subject, key, nil, true)
+
+		var v *Verifier
+		assert.ErrorIs(t, v.Verify(ctx, store, subject), ErrUnverified)
+	})
+}
+
+func TestVerifyConcurrently(t *testing.T) {
+	ctx := context.Background()
+
+	key := generateKey(t)
+	root, rootKey := generateCertificate(t, nil, nil, true)
+
+	verifier, err := NewVerifier(publicKeyPEM(t, &key.PublicKey), certificatePEM(root))
+	require.NoError(t, err)
+
+	// Spare capacity in the trusted keys would be shared by the keys of the certificates appended to them
+	trusted := len(verifier.keys)
+	verifier.keys = append(make([]crypto.PublicKey, 0, trusted+8), verifier.keys...)
+
+	// Each package is signed with a certificate of its own, which must not be trusted for the other packages
+	packages := make([]func() error, 8)
+	for i := range packages {
+		leaf, leafKey := generateCertificate(t, root, rootKey, false)
+		store, subject := newPackage(t)
+		pushCosignSignature(t, store, subject, subject, leafKey, leaf, true)
+		packages[i] = func() error { return verifier.Verify(ctx, store, subject) }
+	}
+
+	errs := make(chan error, len(packages))
+	for _, verify := range packages {
+		go func() { errs <- verify() }()
+	}
+	for range packages {
+		assert.NoError(t, <-errs)
+	}
+
+	assert.Len(t, verifier.keys, trusted)
+	assert.Empty(t, slices.DeleteFunc(verifier.keys[trusted:cap(verifier.keys)], func(key crypto.PublicKey) bool { return key == nil }))
+}
+
+func TestLoadVerifier(t *testing.T) {
+	key := generateKey(t)
+	root, _ := generateCertificate(t, nil, nil, true)
+
+	t.Run("keys and certificates are loaded from the files of a directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cosign.pub"), publicKeyPEM(t, &key.PublicKey), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "root.crt"), certificatePEM(root), 0o600))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "..data"), 0o700))
+
+		v, err := LoadVerifier(dir)
+
+		require.NoError(t, err)
+		assert.Len(t, v.keys, 1)
+		assert.NotNil(t, v.roots)
+	})
+
+	t.Run("unsupported PEM blocks are refused", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "key.pem")
+		require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}), 0o600))
+
+		_, err := LoadVerifier(file)
+
+		assert.ErrorContains(t, err, "unsupported PEM block PRIVATE KEY")
+	})
+
+	t.Run("files without keys are refused", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "empty.pem")
+		require.NoError(t, os.WriteFile(file, []byte("not a key"), 0o600))
+
+		_, err := LoadVerifier(file)
+
+		assert.ErrorContains(t, err, "no trusted public keys or certificates found")
+	})
+}
+
+// newPackage returns a store holding a workflow package, and the descriptor of its manifest
+func newPackage(t *testing.T) (*memory.Store, ocispec.Descriptor) {
+	t.Helper()
+	store := memory.New()
+
+	layer := push(t, store, "application/vnd.oci.image.layer.v1.tar", []byte("package"), nil)
+	return store, pushManifest(t, store, ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    push(t, store, ocispec.MediaTypeEmptyJSON, []byte("{}"), nil),
+		Layers:    []ocispec.Descriptor{layer},
+	})
+}
+
+// pushCosignSignature pushes a cosign signature of the signed manifest, as a referrer of the subject or to the
+// signature tag
+func pushCosignSignature(t *testing.T, store *memory.Store, subject ocispec.Descriptor, signed ocispec.Descriptor, key *ecdsa.PrivateKey, certificate *x509.Certificate, referrer bool) {
+	t.Helper()
+
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"example"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, signed.Digest))
+	digest := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	annotations := map[string]string{AnnotationCosignSignature: base64.StdEncoding.EncodeToString(sig)}
+	if certificate != nil {
+		annotations[AnnotationCosignCertificate] = string(certificatePEM(certificate))
+	}
+
+	manifest := ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    push(t, store, ocispec.MediaTypeEmptyJSON, []byte("{}"), nil),
+		Layers:    []ocispec.Descriptor{push(t, store, MediaTypeCosignPayload, payload, annotations)},
+	}
+	if referrer {
+		manifest.ArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+		manifest.Subject = &subject
+	}
+
+	desc := pushManifest(t, store, manifest)
+	if !referrer {
+		require.NoError(t, store.Tag(context.Background(), desc, CosignTag(subject)))
+	}
+}
+
+// pushNotationSignature pushes a notation JWS signature of the signed manifest as a referrer of the subject
+func pushNotationSignature(t *testing.T, store *memory.Store, subject ocispec.Descriptor, signed ocispec.Descriptor, key *ecdsa.PrivateKey, certificate *x509.Certificate) {
+	t.Helper()
+
+	payload, err := json.Marshal(notationPayload{TargetArtifact: signed})
+	require.NoError(t, err)
+	protected := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256","cty":"application/vnd.cncf.notary.payload.v1+json","io.cncf.notary.signingScheme":"notary.x509"}`))
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := sha256.Sum256([]byte(protected + "." + encodedPayload))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	envelope := jwsEnvelope{Payload: encodedPayload, Protected: protected, Signature: base64.RawURLEncoding.EncodeToString(sig)}
+	envelope.Header.X5C = [][]byte{certificate.Raw}
+	data, err := json.Marshal(envelope)
+	require.NoError(t, err)
+
+	pushManifest(t, store, ocispec.Manifest{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.cncf.notary.signature",
+		Config:       push(t, store, ocispec.MediaTypeEmptyJSON, []byte("{}"), nil),
+		Layers:       []ocispec.Descriptor{push(t, store, MediaTypeNotationJWS, data, nil)},
+		Subject:      &subject,
+	})
+}
+
+func pushManifest(t *testing.T, store *memory.Store, manifest ocispec.Manifest) ocispec.Descriptor {
+	t.Helper()
+	manifest.Versioned.SchemaVersion = 2
+
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, data)
+	desc.ArtifactType = manifest.ArtifactType
+	require.NoError(t, store.Push(context.Background(), desc, bytes.NewReader(data)))
+	return desc
+}
+
+func push(t *testing.T, store *memory.Store, mediaType string, data []byte, annotations map[string]string) ocispec.Descriptor {
+	t.Helper()
+
+	desc := content.NewDescriptorFromBytes(mediaType, data)
+	if exists, _ := store.Exists(context.Background(), desc); !exists {
+		require.NoError(t, store.Push(context.Background(), desc, bytes.NewReader(data)))
+	}
+	desc.Annotations = annotations
+	return desc
+}
+
+func generateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+// generateCertificate generates a CA certificate, or a code signing certificate issued by the parent
+func generateCertificate(t *testing.T, parent *x509.Certificate, parentKey crypto.Signer, ca bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key := generateKey(t)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if ca {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certificate, key
+}
+
+func publicKeyPEM(t *testing.T, key crypto.PublicKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func certificatePEM(certificate *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+}
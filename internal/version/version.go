@@ -0,0 +1,67 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package version resolves the version policies of FlyteRegistrations, semver ranges such as `~1.4` or `>=2.0.0 <3`,
+// against the versions of a workflow package published at its source
+package version
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ErrNoMatch is returned when none of the published versions matches a version policy
+var ErrNoMatch = errors.New("no version matches the version policy")
+
+// ValidatePolicy returns an error when a version policy is not a valid semver range
+func ValidatePolicy(policy string) error {
+	if _, err := semver.NewConstraint(policy); err != nil {
+		return fmt.Errorf("invalid version policy %q: %w", policy, err)
+	}
+
+	return nil
+}
+
+// Resolve returns the highest of the versions that matches the version policy, as it was published, e.g. with a `v`
+// prefix. Versions that are not semver are ignored, and pre-releases only match policies that name a pre-release.
+func Resolve(policy string, versions []string) (string, error) {
+	constraint, err := semver.NewConstraint(policy)
+	if err != nil {
+		return "", fmt.Errorf("invalid version policy %q: %w", policy, err)
+	}
+
+	var latest *semver.Version
+	var resolved string
+	for _, v := range versions {
+		parsed, err := semver.NewVersion(v)
+		if err != nil || !constraint.Check(parsed) {
+			continue
+		}
+
+		if latest == nil || parsed.GreaterThan(latest) {
+			latest = parsed
+			resolved = v
+		}
+	}
+
+	if latest == nil {
+		return "", fmt.Errorf("%w %q in %d versions", ErrNoMatch, policy, len(versions))
+	}
+
+	return resolved, nil
+}
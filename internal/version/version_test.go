@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	versions := []string{"1.3.9", "1.4.0", "v1.4.2", "1.4.10", "1.5.0", "2.0.0", "2.1.0-rc.1", "2.1.0", "3.0.0", "latest", "sha-1a2b3c"}
+
+	tests := []struct {
+		name    string
+		policy  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "tilde ranges allow patch releases",
+			policy: "~1.4",
+			want:   "1.4.10",
+		},
+		{
+			name:   "caret ranges allow minor releases",
+			policy: "^1.4",
+			want:   "1.5.0",
+		},
+		{
+			name:   "ranges can be bounded",
+			policy: ">=2.0.0 <3",
+			want:   "2.1.0",
+		},
+		{
+			name:   "versions keep their prefix",
+			policy: "1.4.2",
+			want:   "v1.4.2",
+		},
+		{
+			name:   "pre-releases only match ranges that name one",
+			policy: ">=2.1.0-rc.0 <=2.1.0-rc.9",
+			want:   "2.1.0-rc.1",
+		},
+		{
+			name:    "failure case: no matching version",
+			policy:  ">=4",
+			wantErr: ErrNoMatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(tt.policy, versions)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	assert.NoError(t, ValidatePolicy("~1.4"))
+	assert.NoError(t, ValidatePolicy(">=2.0.0 <3"))
+	assert.ErrorContains(t, ValidatePolicy("not a range"), `invalid version policy "not a range"`)
+}
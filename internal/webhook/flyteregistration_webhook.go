@@ -0,0 +1,317 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook holds the admission webhooks that default and validate FlyteRegistrations before they are stored,
+// so that invalid specs are rejected by the API server instead of failing in flytectl
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/downloader"
+	"github.com/adarga-ai/flyte-workflow-registration-operator/internal/version"
+)
+
+// versionPattern matches the workflow versions flyte admin accepts. The version is part of the flyte admin API paths
+// and of the storage paths of the registered entities, so only letters, digits, `.`, `_` and `-` are allowed
+var versionPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// minVersionPollInterval is the shortest interval the versions of a workflow package can be polled at, so that the
+// registries are not listed on every reconciliation
+const minVersionPollInterval = time.Minute
+
+// FlyteRegistrationDefaulter fills in the domain and the source type of a FlyteRegistration that does not set them
+type FlyteRegistrationDefaulter struct {
+	DefaultDomain     string
+	DefaultSourceType string
+}
+
+// FlyteRegistrationValidator rejects FlyteRegistrations that flytectl would fail to register
+type FlyteRegistrationValidator struct {
+	// Schemes are the package URI schemes the operator can download
+	Schemes []string
+	// DefaultSourceType is the download strategy of the package URIs without a scheme and a source type
+	DefaultSourceType string
+}
+
+// SetupWithManager registers the defaulting and validating webhooks for FlyteRegistrations with the Manager
+func SetupWithManager(mgr ctrl.Manager, cfg internal.Config) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1.FlyteRegistration{}).
+		WithDefaulter(&FlyteRegistrationDefaulter{
+			DefaultDomain:     cfg.DefaultWorkflowDomain,
+			DefaultSourceType: cfg.DownloadStrategy,
+		}).
+		WithValidator(&FlyteRegistrationValidator{
+			Schemes:           downloader.Schemes(cfg),
+			DefaultSourceType: cfg.DownloadStrategy,
+		}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-flyte-backend-v1-flyteregistration,mutating=true,failurePolicy=fail,sideEffects=None,groups=flyte.backend,resources=flyteregistrations,verbs=create;update,versions=v1,name=mflyteregistration.kb.io,admissionReviewVersions=v1
+
+// Default sets the domain and the source type of a FlyteRegistration when they are empty. The domain is only defaulted
+// without targets, and the source type is taken from the scheme of the package URI, or the default download strategy
+// for URIs without a scheme.
+func (d *FlyteRegistrationDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	flyteWorkflow, ok := obj.(*v1.FlyteRegistration)
+	if !ok {
+		return fmt.Errorf("expected a FlyteRegistration but got a %T", obj)
+	}
+
+	// The targets of a FlyteRegistration each name the domain they are registered in
+	if flyteWorkflow.Spec.WorkflowDomain == "" && len(flyteWorkflow.Spec.Targets) == 0 {
+		flyteWorkflow.Spec.WorkflowDomain = d.DefaultDomain
+	}
+
+	if flyteWorkflow.Spec.Source == nil {
+		flyteWorkflow.Spec.Source = &v1.SourceSpec{}
+	}
+
+	if flyteWorkflow.Spec.Source.Type == "" {
+		flyteWorkflow.Spec.Source.Type = downloader.Strategy(flyteWorkflow.Spec.WorkflowPackageURI, "", d.DefaultSourceType)
+	}
+
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-flyte-backend-v1-flyteregistration,mutating=false,failurePolicy=fail,sideEffects=None,groups=flyte.backend,resources=flyteregistrations,verbs=create;update,versions=v1,name=vflyteregistration.kb.io,admissionReviewVersions=v1
+
+// ValidateCreate validates the spec of a new FlyteRegistration
+func (v *FlyteRegistrationValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	flyteWorkflow, ok := obj.(*v1.FlyteRegistration)
+	if !ok {
+		return nil, fmt.Errorf("expected a FlyteRegistration but got a %T", obj)
+	}
+
+	return nil, invalid(flyteWorkflow, v.validateSpec(flyteWorkflow.Spec))
+}
+
+// ValidateUpdate validates the spec of an updated FlyteRegistration, and that its project and domain are unchanged.
+// The registered entities are not moved in flyte, so changing them would leave the old entities behind. The domain can
+// only be cleared to move the FlyteRegistration to targets that keep registering into its project and domain.
+func (v *FlyteRegistrationValidator) ValidateUpdate(_ context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	oldWorkflow, ok := oldObj.(*v1.FlyteRegistration)
+	if !ok {
+		return nil, fmt.Errorf("expected a FlyteRegistration but got a %T", oldObj)
+	}
+
+	flyteWorkflow, ok := newObj.(*v1.FlyteRegistration)
+	if !ok {
+		return nil, fmt.Errorf("expected a FlyteRegistration but got a %T", newObj)
+	}
+
+	// Let objects that are being deleted through, so that an invalid spec cannot block removing the finalizer
+	if !flyteWorkflow.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	specPath := field.NewPath("spec")
+	errs := v.validateSpec(flyteWorkflow.Spec)
+
+	if flyteWorkflow.Spec.WorkflowProject != oldWorkflow.Spec.WorkflowProject {
+		errs = append(errs, field.Forbidden(specPath.Child("workflowProject"), "field is immutable"))
+	}
+
+	if flyteWorkflow.Spec.WorkflowDomain != oldWorkflow.Spec.WorkflowDomain && !migratedToTargets(oldWorkflow.Spec, flyteWorkflow.Spec) {
+		errs = append(errs, field.Forbidden(specPath.Child("workflowDomain"), "field is immutable"))
+	}
+
+	return nil, invalid(flyteWorkflow, errs)
+}
+
+// ValidateDelete allows every FlyteRegistration to be deleted
+func (v *FlyteRegistrationValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSpec returns the errors in a FlyteRegistration spec
+func (v *FlyteRegistrationValidator) validateSpec(spec v1.FlyteRegistrationSpec) field.ErrorList {
+	specPath := field.NewPath("spec")
+	var errs field.ErrorList
+
+	errs = append(errs, validateName(specPath.Child("workflowProject"), spec.WorkflowProject)...)
+	if len(spec.Targets) == 0 {
+		errs = append(errs, validateName(specPath.Child("workflowDomain"), spec.WorkflowDomain)...)
+	} else {
+		errs = append(errs, validateTargets(specPath, spec)...)
+	}
+
+	versionPath := specPath.Child("workflowVersion")
+	switch {
+	case spec.VersionPolicy != "" && spec.WorkflowVersion != "":
+		errs = append(errs, field.Forbidden(versionPath, "may not be set with a versionPolicy"))
+	case spec.VersionPolicy != "":
+		errs = append(errs, validateVersionPolicy(specPath, spec)...)
+	case spec.WorkflowVersion == "":
+		errs = append(errs, field.Required(versionPath, "workflowVersion or versionPolicy is required"))
+	default:
+		errs = append(errs, validateVersion(versionPath, spec.WorkflowVersion)...)
+	}
+
+	uriPath := specPath.Child("workflowPackageUri")
+	if spec.WorkflowPackageURI == "" {
+		errs = append(errs, field.Required(uriPath, ""))
+		return errs
+	}
+
+	scheme, _ := downloader.ParseURI(spec.WorkflowPackageURI)
+	if spec.VersionPolicy != "" {
+		// The versions are listed with the strategy the controller downloads the package with
+		var sourceType string
+		if spec.Source != nil {
+			sourceType = spec.Source.Type
+		}
+		strategy := downloader.Strategy(spec.WorkflowPackageURI, sourceType, v.DefaultSourceType)
+		if strategy == internal.DownloadStrategyS3 || strategy == internal.DownloadStrategyHTTPS {
+			errs = append(errs, field.Invalid(specPath.Child("versionPolicy"), spec.VersionPolicy,
+				fmt.Sprintf("the versions of %s packages cannot be listed, only oci and jfrog packages support a version policy", strategy)))
+		}
+	}
+	if scheme != "" && !slices.Contains(v.Schemes, scheme) {
+		errs = append(errs, field.NotSupported(uriPath, scheme, v.Schemes))
+	}
+
+	if spec.Source != nil && spec.Source.Type != "" {
+		typePath := specPath.Child("source", "type")
+		switch {
+		case !slices.Contains(v.Schemes, spec.Source.Type):
+			errs = append(errs, field.NotSupported(typePath, spec.Source.Type, v.Schemes))
+		case scheme != "" && scheme != spec.Source.Type:
+			errs = append(errs, field.Invalid(typePath, spec.Source.Type,
+				fmt.Sprintf("does not match the scheme %s of the package URI", scheme)))
+		}
+	}
+
+	return errs
+}
+
+// migratedToTargets returns true when an update clears the domain of a spec without targets, and adds a target for
+// the project and domain it registered into
+func migratedToTargets(oldSpec v1.FlyteRegistrationSpec, spec v1.FlyteRegistrationSpec) bool {
+	if spec.WorkflowDomain != "" || len(oldSpec.Targets) > 0 {
+		return false
+	}
+
+	for _, target := range spec.Targets {
+		project := target.Project
+		if project == "" {
+			project = spec.WorkflowProject
+		}
+		if project == oldSpec.WorkflowProject && target.Domain == oldSpec.WorkflowDomain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateTargets returns the errors in the targets of a spec. Each target must name a domain, and the workflow package
+// cannot be registered into the same project and domain twice
+func validateTargets(specPath *field.Path, spec v1.FlyteRegistrationSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	if spec.WorkflowDomain != "" {
+		errs = append(errs, field.Forbidden(specPath.Child("workflowDomain"), "may not be set with targets"))
+	}
+
+	seen := make(map[string]bool, len(spec.Targets))
+	for i, target := range spec.Targets {
+		targetPath := specPath.Child("targets").Index(i)
+		errs = append(errs, validateName(targetPath.Child("domain"), target.Domain)...)
+
+		project := spec.WorkflowProject
+		if target.Project != "" {
+			project = target.Project
+			errs = append(errs, validateName(targetPath.Child("project"), target.Project)...)
+		}
+
+		if target.WorkflowVersion != "" {
+			errs = append(errs, validateVersion(targetPath.Child("workflowVersion"), target.WorkflowVersion)...)
+		}
+
+		key := project + "/" + target.Domain
+		if seen[key] {
+			errs = append(errs, field.Duplicate(targetPath, key))
+		}
+		seen[key] = true
+	}
+
+	return errs
+}
+
+// validateVersion returns an error when a workflow version is not accepted by flyte admin
+func validateVersion(path *field.Path, workflowVersion string) field.ErrorList {
+	if !versionPattern.MatchString(workflowVersion) {
+		return field.ErrorList{field.Invalid(path, workflowVersion,
+			"must start with a letter or digit and only contain letters, digits, '.', '_' and '-'")}
+	}
+
+	return nil
+}
+
+// validateVersionPolicy returns the errors in the version policy of a spec and its poll interval
+func validateVersionPolicy(specPath *field.Path, spec v1.FlyteRegistrationSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	if err := version.ValidatePolicy(spec.VersionPolicy); err != nil {
+		errs = append(errs, field.Invalid(specPath.Child("versionPolicy"), spec.VersionPolicy, err.Error()))
+	}
+
+	if spec.VersionPollInterval != nil && spec.VersionPollInterval.Duration < minVersionPollInterval {
+		errs = append(errs, field.Invalid(specPath.Child("versionPollInterval"), spec.VersionPollInterval.Duration.String(),
+			fmt.Sprintf("must be at least %s", minVersionPollInterval)))
+	}
+
+	return errs
+}
+
+// validateName returns an error when a flyte project or domain name is empty or is not a DNS-1123 label
+func validateName(path *field.Path, name string) field.ErrorList {
+	if name == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+
+	var errs field.ErrorList
+	for _, msg := range validation.IsDNS1123Label(name) {
+		errs = append(errs, field.Invalid(path, name, msg))
+	}
+
+	return errs
+}
+
+// invalid returns an Invalid API error for the FlyteRegistration when there are errors
+func invalid(flyteWorkflow *v1.FlyteRegistration, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(v1.GroupVersion.WithKind("FlyteRegistration").GroupKind(), flyteWorkflow.Name, errs)
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/adarga-ai/flyte-workflow-registration-operator/api/v1"
+)
+
+func newFlyteRegistration() *v1.FlyteRegistration {
+	return &v1.FlyteRegistration{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-flyte-workflow", Namespace: "test-namespace"},
+		Spec: v1.FlyteRegistrationSpec{
+			WorkflowDomain:     "development",
+			WorkflowProject:    "test-project",
+			WorkflowPackageURI: "oci://adarga/example-workflow",
+			WorkflowVersion:    "1.0.0",
+		},
+	}
+}
+
+func TestDefault(t *testing.T) {
+	defaulter := &FlyteRegistrationDefaulter{DefaultDomain: "development", DefaultSourceType: "jfrog"}
+
+	t.Run("domain and source type are defaulted", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowDomain = ""
+		flyteWorkflow.Spec.WorkflowPackageURI = "repo/example-workflow"
+
+		err := defaulter.Default(context.Background(), flyteWorkflow)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "development", flyteWorkflow.Spec.WorkflowDomain)
+		assert.Equal(t, "jfrog", flyteWorkflow.Spec.Source.Type)
+	})
+
+	t.Run("source type is taken from the uri scheme", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowDomain = "production"
+
+		err := defaulter.Default(context.Background(), flyteWorkflow)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "production", flyteWorkflow.Spec.WorkflowDomain)
+		assert.Equal(t, "oci", flyteWorkflow.Spec.Source.Type)
+	})
+
+	t.Run("domain is not defaulted with targets", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowDomain = ""
+		flyteWorkflow.Spec.Targets = []v1.RegistrationTarget{{Domain: "staging"}}
+
+		err := defaulter.Default(context.Background(), flyteWorkflow)
+
+		assert.NoError(t, err)
+		assert.Empty(t, flyteWorkflow.Spec.WorkflowDomain)
+	})
+}
+
+func TestValidateCreate(t *testing.T) {
+	validator := &FlyteRegistrationValidator{Schemes: []string{"oci", "jfrog"}}
+
+	t.Run("success case", func(t *testing.T) {
+		_, err := validator.ValidateCreate(context.Background(), newFlyteRegistration())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: empty project, domain and version", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowProject = ""
+		flyteWorkflow.Spec.WorkflowDomain = ""
+		flyteWorkflow.Spec.WorkflowVersion = ""
+
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "spec.workflowProject: Required value")
+		assert.ErrorContains(t, err, "spec.workflowDomain: Required value")
+		assert.ErrorContains(t, err, "spec.workflowVersion: Required value")
+	})
+
+	t.Run("failure case: invalid version", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowVersion = "1.0.0/latest"
+
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "spec.workflowVersion: Invalid value")
+	})
+
+	t.Run("failure case: invalid project", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowProject = "Test_Project"
+
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "spec.workflowProject: Invalid value")
+	})
+
+	t.Run("failure case: unsupported uri scheme", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowPackageURI = "ftp://example.com/example-workflow"
+
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "spec.workflowPackageUri: Unsupported value: \"ftp\"")
+	})
+
+	t.Run("failure case: source type does not match the uri scheme", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.Source = &v1.SourceSpec{Type: "jfrog"}
+
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "spec.source.type: Invalid value")
+	})
+
+	t.Run("success case: version policy instead of a version", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowVersion = ""
+		flyteWorkflow.Spec.VersionPolicy = ">=2.0.0 <3"
+		flyteWorkflow.Spec.VersionPollInterval = &metav1.Duration{Duration: 10 * time.Minute}
+
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: version and version policy", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.VersionPolicy = "~1.4"
+
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "spec.workflowVersion: Forbidden")
+	})
+
+	t.Run("failure case: invalid version policy and poll interval", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowVersion = ""
+		flyteWorkflow.Spec.VersionPolicy = "latest"
+		flyteWorkflow.Spec.VersionPollInterval = &metav1.Duration{Duration: time.Second}
+
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "spec.versionPolicy: Invalid value")
+		assert.ErrorContains(t, err, "spec.versionPollInterval: Invalid value")
+	})
+
+	t.Run("failure case: version policy of a source that cannot list versions", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowVersion = ""
+		flyteWorkflow.Spec.WorkflowPackageURI = "flyte-packages/example-workflow/{version}/package.tgz"
+		flyteWorkflow.Spec.Source = &v1.SourceSpec{Type: "s3"}
+		flyteWorkflow.Spec.VersionPolicy = "~1.4"
+
+		_, err := (&FlyteRegistrationValidator{Schemes: []string{"oci", "s3"}}).ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "the versions of s3 packages cannot be listed")
+	})
+
+	t.Run("failure case: version policy of a bare uri downloaded with a strategy that cannot list versions", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowVersion = ""
+		flyteWorkflow.Spec.WorkflowPackageURI = "example.com/example-workflow/{version}/package.tgz"
+		flyteWorkflow.Spec.VersionPolicy = "~1.4"
+
+		validator := &FlyteRegistrationValidator{Schemes: []string{"oci", "https"}, DefaultSourceType: "https"}
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "the versions of https packages cannot be listed")
+	})
+
+	t.Run("success case: targets instead of a domain", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowDomain = ""
+		flyteWorkflow.Spec.Targets = []v1.RegistrationTarget{
+			{Domain: "development"},
+			{Domain: "staging"},
+			{Domain: "production", Project: "other-project", WorkflowVersion: "0.9.0"},
+		}
+
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: invalid targets", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.Targets = []v1.RegistrationTarget{
+			{Domain: "staging"},
+			{Domain: ""},
+			{Domain: "staging", Project: "test-project"},
+			{Domain: "production", WorkflowVersion: "1.0.0/latest"},
+		}
+
+		_, err := validator.ValidateCreate(context.Background(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "spec.workflowDomain: Forbidden: may not be set with targets")
+		assert.ErrorContains(t, err, "spec.targets[1].domain: Required value")
+		assert.ErrorContains(t, err, `spec.targets[2]: Duplicate value: "test-project/staging"`)
+		assert.ErrorContains(t, err, "spec.targets[3].workflowVersion: Invalid value")
+	})
+}
+
+func TestValidateUpdate(t *testing.T) {
+	validator := &FlyteRegistrationValidator{Schemes: []string{"oci"}}
+
+	t.Run("success case: version is changed", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowVersion = "1.1.0"
+
+		_, err := validator.ValidateUpdate(context.Background(), newFlyteRegistration(), flyteWorkflow)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: project and domain are immutable", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowProject = "other-project"
+		flyteWorkflow.Spec.WorkflowDomain = "production"
+
+		_, err := validator.ValidateUpdate(context.Background(), newFlyteRegistration(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "spec.workflowProject: Forbidden: field is immutable")
+		assert.ErrorContains(t, err, "spec.workflowDomain: Forbidden: field is immutable")
+	})
+
+	t.Run("success case: domain is moved to targets", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowDomain = ""
+		flyteWorkflow.Spec.Targets = []v1.RegistrationTarget{{Domain: "development"}, {Domain: "production"}}
+
+		_, err := validator.ValidateUpdate(context.Background(), newFlyteRegistration(), flyteWorkflow)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure case: domain is cleared without a target for it", func(t *testing.T) {
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.Spec.WorkflowDomain = ""
+		flyteWorkflow.Spec.Targets = []v1.RegistrationTarget{{Domain: "production"}, {Domain: "development", Project: "other-project"}}
+
+		_, err := validator.ValidateUpdate(context.Background(), newFlyteRegistration(), flyteWorkflow)
+
+		assert.ErrorContains(t, err, "spec.workflowDomain: Forbidden: field is immutable")
+	})
+
+	t.Run("deleted objects are not validated", func(t *testing.T) {
+		now := metav1.Now()
+		flyteWorkflow := newFlyteRegistration()
+		flyteWorkflow.DeletionTimestamp = &now
+		flyteWorkflow.Spec.WorkflowProject = "other-project"
+
+		_, err := validator.ValidateUpdate(context.Background(), newFlyteRegistration(), flyteWorkflow)
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package workspace hands out the working directories workflow packages are downloaded to.
+
+Every reconciliation gets its own uniquely named directory under a root directory, so concurrent reconciliations and
+packages with the same name never share files, and the directory is removed once the package has been registered.
+The downloads in all the workspaces share a disk usage cap, a download reserves the size of the files it writes and
+is refused when the reservation would exceed the cap.
+*/
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ErrCapExceeded is returned when a download would take the disk usage of the workspaces over the cap
+var ErrCapExceeded = errors.New("workspace disk usage cap exceeded")
+
+// Manager creates the workspaces under a root directory and accounts for their disk usage
+type Manager struct {
+	root     string
+	maxBytes int64
+
+	mu       sync.Mutex
+	reserved int64
+}
+
+// Workspace is a directory a single reconciliation downloads to
+type Workspace struct {
+	// Dir is the path of the directory of the workspace
+	Dir string
+
+	manager  *Manager
+	reserved int64
+}
+
+// NewManager returns a Manager for workspaces under the root directory, the temp directory when the root is empty.
+// The downloads in all the workspaces are capped to maxBytes, there is no cap when it is 0.
+func NewManager(root string, maxBytes int64) *Manager {
+	if root == "" {
+		root = os.TempDir()
+	}
+
+	return &Manager{
+		root:     root,
+		maxBytes: maxBytes,
+	}
+}
+
+// Create creates a new workspace with a unique directory, the name is used as a prefix of the directory name
+func (m *Manager) Create(name string) (*Workspace, error) {
+	if err := os.MkdirAll(m.root, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create workspace root: %w", err)
+	}
+
+	dir, err := os.MkdirTemp(m.root, name+"-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	return &Workspace{Dir: dir, manager: m}, nil
+}
+
+// Reserve reserves disk space for a file of the given size before it is written to the workspace. It returns
+// ErrCapExceeded when the reservation would take the disk usage of the workspaces over the cap.
+func (w *Workspace) Reserve(size int64) error {
+	m := w.manager
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.maxBytes > 0 && m.reserved+size > m.maxBytes {
+		return fmt.Errorf("%w: %d bytes in use, %d bytes requested, the cap is %d bytes", ErrCapExceeded, m.reserved, size, m.maxBytes)
+	}
+
+	m.reserved += size
+	w.reserved += size
+	return nil
+}
+
+// Remove deletes the directory of the workspace and releases the disk space it reserved
+func (w *Workspace) Remove() error {
+	m := w.manager
+	m.mu.Lock()
+	m.reserved -= w.reserved
+	w.reserved = 0
+	m.mu.Unlock()
+
+	if err := os.RemoveAll(w.Dir); err != nil {
+		return fmt.Errorf("failed to remove workspace: %w", err)
+	}
+
+	return nil
+}
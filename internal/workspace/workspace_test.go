@@ -0,0 +1,78 @@
+/*
+Copyright 2025 Adarga Limited.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "workspaces")
+	manager := NewManager(root, 0)
+
+	t.Run("workspaces with the same name get their own directory", func(t *testing.T) {
+		first, err := manager.Create("test-flyte-workflow")
+		require.NoError(t, err)
+		second, err := manager.Create("test-flyte-workflow")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first.Dir, second.Dir)
+		assert.Equal(t, root, filepath.Dir(first.Dir))
+		assert.DirExists(t, first.Dir)
+		assert.DirExists(t, second.Dir)
+	})
+
+	t.Run("removing a workspace deletes its files", func(t *testing.T) {
+		ws, err := manager.Create("test-flyte-workflow")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(ws.Dir, "package.tgz"), []byte("package"), 0o600))
+
+		err = ws.Remove()
+
+		assert.NoError(t, err)
+		assert.NoDirExists(t, ws.Dir)
+	})
+}
+
+func TestReserve(t *testing.T) {
+	manager := NewManager(t.TempDir(), 10)
+
+	first, err := manager.Create("first")
+	require.NoError(t, err)
+	second, err := manager.Create("second")
+	require.NoError(t, err)
+
+	t.Run("reservations within the cap are allowed", func(t *testing.T) {
+		assert.NoError(t, first.Reserve(6))
+		assert.NoError(t, second.Reserve(4))
+	})
+
+	t.Run("reservations over the cap are refused", func(t *testing.T) {
+		assert.ErrorIs(t, second.Reserve(1), ErrCapExceeded)
+	})
+
+	t.Run("removing a workspace releases its reservations", func(t *testing.T) {
+		require.NoError(t, first.Remove())
+
+		assert.NoError(t, second.Reserve(6))
+	})
+}